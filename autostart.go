@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/CGA1123/tomato/config"
+)
+
+var (
+	Autostart        = false
+	AutostartTimeout = 5 * time.Second
+)
+
+// startServer launches `tomato server` in the background, detached from the
+// current session, and waits for it to start accepting connections.
+func startServer() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("error finding tomato executable: %w", err)
+	}
+
+	devnull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer devnull.Close()
+
+	// Anything the server prints before it takes over its log file, such as
+	// why it refused to start, is appended to the log file too.
+	if err := config.EnsureDir(filepath.Dir(LogFile)); err != nil {
+		return err
+	}
+
+	logFile, err := os.OpenFile(LogFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("error opening log file: %w", err)
+	}
+	defer logFile.Close()
+
+	// Pass along the paths in use so that the server agrees with us on where
+	// to listen, whatever flags we were given. The default config file is
+	// left for the server to find, as it may not exist.
+	args := []string{"server", "--socket", Socket, "--pid-file", PidFile, "--log-file", LogFile}
+	if configGiven {
+		args = append(args, "--config", ConfigFile)
+	}
+
+	cmd := exec.Command(exe, args...)
+	cmd.Stdin = devnull
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("error starting tomato server: %w", err)
+	}
+
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	return waitForSocket(exited)
+}
+
+// waitForSocket polls the server's socket until it accepts a connection, the
+// server exits or AutostartTimeout passes.
+func waitForSocket(exited <-chan error) error {
+	deadline := time.After(AutostartTimeout)
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-exited:
+			return fmt.Errorf("tomato server exited during startup (%v), see %v", err, LogFile)
+		case <-deadline:
+			return fmt.Errorf("tomato server did not start within %v, see %v", AutostartTimeout, LogFile)
+		case <-ticker.C:
+			conn, err := net.Dial("unix", Socket)
+			if err == nil {
+				conn.Close()
+				return nil
+			}
+		}
+	}
+}
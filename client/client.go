@@ -2,28 +2,269 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/CGA1123/tomato/pb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Client makes calls to the tomato server. Each call has a Context variant,
+// e.g. StartContext, which the call is bound by, the others use
+// context.Background() and are only bound by WithTimeout.
 type Client struct {
+	conn   *grpc.ClientConn
 	client pb.TomatoServiceClient
+
+	token       string
+	tls         *tls.Config
+	creds       credentials.TransportCredentials
+	dialer      func(context.Context, string) (net.Conn, error)
+	timeout     time.Duration
+	retry       *RetryPolicy
+	keepalive   *keepalive.ClientParameters
+	unary       []grpc.UnaryClientInterceptor
+	stream      []grpc.StreamClientInterceptor
+	dialOptions []grpc.DialOption
+}
+
+type Option func(*Client)
+
+// WithToken authenticates every call with token, it requires WithTLS or
+// WithTransportCredentials.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithTLS connects to the server over TLS using config.
+func WithTLS(config *tls.Config) Option {
+	return func(c *Client) {
+		c.tls = config
+	}
+}
+
+// WithTransportCredentials secures the connection to the server with creds,
+// taking precedence over WithTLS.
+func WithTransportCredentials(creds credentials.TransportCredentials) Option {
+	return func(c *Client) {
+		c.creds = creds
+	}
 }
 
-func New(socket string) (*Client, error) {
-	conn, err := grpc.Dial("unix://"+socket, grpc.WithInsecure())
+// WithDialer connects to the server using dial, which is passed the target
+// given to New as is, e.g. to reach a server listening in memory.
+func WithDialer(dial func(ctx context.Context, target string) (net.Conn, error)) Option {
+	return func(c *Client) {
+		c.dialer = dial
+	}
+}
+
+// WithTimeout gives up on any call, other than Watch, which takes longer than
+// d and was not already given a deadline. The timeout covers any retries.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithRetry retries calls, other than Watch, as described by policy.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = &policy
+	}
+}
+
+// WithKeepalive pings the server as described by params, to notice a
+// connection which has silently gone away, e.g. while watching.
+func WithKeepalive(params keepalive.ClientParameters) Option {
+	return func(c *Client) {
+		c.keepalive = &params
+	}
+}
+
+// WithUnaryInterceptor intercepts every call other than Watch, interceptors
+// being called in the order given, after any timeout and retries are applied.
+func WithUnaryInterceptor(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return func(c *Client) {
+		c.unary = append(c.unary, interceptors...)
+	}
+}
+
+// WithStreamInterceptor intercepts Watch, interceptors being called in the
+// order given.
+func WithStreamInterceptor(interceptors ...grpc.StreamClientInterceptor) Option {
+	return func(c *Client) {
+		c.stream = append(c.stream, interceptors...)
+	}
+}
+
+// WithDialOptions passes opts to grpc.Dial after those set by other options,
+// for anything they do not cover.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(c *Client) {
+		c.dialOptions = append(c.dialOptions, opts...)
+	}
+}
+
+// New connects to the server at target, which is one of:
+//
+//	/path/to/tomato.sock or unix:///path/to/tomato.sock
+//	tcp://host:port
+//	anything grpc.Dial understands with a scheme, e.g. dns:///host:port
+//	anything understood by WithDialer, e.g. bufconn, which is passed as is
+//
+// No connection is made until the first call. The client should be closed
+// once done with.
+func New(target string, opts ...Option) (*Client, error) {
+	c := &Client{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	creds := c.creds
+	if creds == nil && c.tls != nil {
+		creds = credentials.NewTLS(c.tls)
+	}
+
+	dialOpts := []grpc.DialOption{grpc.WithInsecure()}
+	if creds != nil {
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	}
+
+	if c.token != "" {
+		if creds == nil {
+			return nil, fmt.Errorf("a token may only be sent over TLS")
+		}
+
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(bearer(c.token)))
+	}
+
+	unary := []grpc.UnaryClientInterceptor{}
+	if c.timeout > 0 {
+		unary = append(unary, deadline(c.timeout))
+	}
+
+	if c.retry != nil {
+		unary = append(unary, c.retry.interceptor())
+	}
+
+	unary = append(unary, c.unary...)
+	if len(unary) > 0 {
+		dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(unary...))
+	}
+
+	if len(c.stream) > 0 {
+		dialOpts = append(dialOpts, grpc.WithChainStreamInterceptor(c.stream...))
+	}
+
+	if c.keepalive != nil {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(*c.keepalive))
+	}
+
+	if c.dialer != nil {
+		dialOpts = append(dialOpts, grpc.WithContextDialer(c.dialer))
+	}
+
+	conn, err := grpc.Dial(c.parseTarget(target), append(dialOpts, c.dialOptions...)...)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{client: pb.NewTomatoServiceClient(conn)}, nil
+	c.conn = conn
+	c.client = pb.NewTomatoServiceClient(conn)
+
+	return c, nil
+}
+
+// Close closes the connection to the server, any calls still in flight are
+// cancelled.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// parseTarget turns target, as given to New, into one grpc.Dial understands.
+func (c *Client) parseTarget(target string) string {
+	switch {
+	case c.dialer != nil:
+		return "passthrough:///" + target
+	case strings.HasPrefix(target, "tcp://"):
+		return strings.TrimPrefix(target, "tcp://")
+	case strings.HasPrefix(target, "unix:"), strings.Contains(target, "://"):
+		return target
+	default:
+		return "unix://" + target
+	}
+}
+
+// deadline bounds unary calls without a deadline of their own to timeout.
+func deadline(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
 }
 
-func (c *Client) Start() (time.Time, error) {
-	endsAt, err := c.client.Start(context.Background(), &emptypb.Empty{})
+// bearer sends a token in the authorization metadata of each call.
+type bearer string
+
+func (b bearer) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(b)}, nil
+}
+
+func (b bearer) RequireTransportSecurity() bool {
+	return true
+}
+
+// Start starts a new tomato lasting for d, if d is zero the server default is
+// used instead.
+func (c *Client) Start(d time.Duration) (time.Time, error) {
+	return c.StartContext(context.Background(), d)
+}
+
+// StartContext is Start, bound by ctx.
+func (c *Client) StartContext(ctx context.Context, d time.Duration) (time.Time, error) {
+	return c.StartWithContext(ctx, StartOptions{Duration: d})
+}
+
+// StartOptions describe a tomato to start.
+type StartOptions struct {
+	// Duration is how long the tomato lasts, the server default if zero.
+	Duration time.Duration
+	// Label and Tags describe what the tomato will be spent on.
+	Label string
+	Tags  []string
+	// Task is the id of a task to count the tomato against, if any.
+	Task uint32
+}
+
+// StartWith starts a new tomato as described by opts.
+func (c *Client) StartWith(opts StartOptions) (time.Time, error) {
+	return c.StartWithContext(context.Background(), opts)
+}
+
+// StartWithContext is StartWith, bound by ctx.
+func (c *Client) StartWithContext(ctx context.Context, opts StartOptions) (time.Time, error) {
+	req := &pb.StartRequest{Label: opts.Label, Tags: opts.Tags, Task: opts.Task}
+	if opts.Duration != 0 {
+		req.Duration = durationpb.New(opts.Duration)
+	}
+
+	endsAt, err := c.client.Start(ctx, req)
 	if err != nil {
 		return time.Now(), err
 	}
@@ -32,7 +273,22 @@ func (c *Client) Start() (time.Time, error) {
 }
 
 func (c *Client) Stop() (time.Duration, error) {
-	left, err := c.client.Stop(context.Background(), &emptypb.Empty{})
+	return c.StopContext(context.Background())
+}
+
+// StopContext is Stop, bound by ctx.
+func (c *Client) StopContext(ctx context.Context) (time.Duration, error) {
+	return c.StopWithReasonContext(ctx, "")
+}
+
+// StopWithReason stops the current tomato or break, recording why.
+func (c *Client) StopWithReason(reason string) (time.Duration, error) {
+	return c.StopWithReasonContext(context.Background(), reason)
+}
+
+// StopWithReasonContext is StopWithReason, bound by ctx.
+func (c *Client) StopWithReasonContext(ctx context.Context, reason string) (time.Duration, error) {
+	left, err := c.client.Stop(ctx, &pb.StopRequest{Reason: reason})
 	if err != nil {
 		return time.Duration(0), err
 	}
@@ -41,7 +297,12 @@ func (c *Client) Stop() (time.Duration, error) {
 }
 
 func (c *Client) Remaining() (time.Duration, error) {
-	left, err := c.client.Remaining(context.Background(), &emptypb.Empty{})
+	return c.RemainingContext(context.Background())
+}
+
+// RemainingContext is Remaining, bound by ctx.
+func (c *Client) RemainingContext(ctx context.Context) (time.Duration, error) {
+	left, err := c.client.Remaining(ctx, &emptypb.Empty{})
 	if err != nil {
 		return time.Duration(0), err
 	}
@@ -49,11 +310,208 @@ func (c *Client) Remaining() (time.Duration, error) {
 	return left.AsDuration(), err
 }
 
-func (c *Client) Running() (bool, error) {
-	running, err := c.client.Running(context.Background(), &emptypb.Empty{})
+func (c *Client) Running() (pb.State, error) {
+	return c.RunningContext(context.Background())
+}
+
+// RunningContext is Running, bound by ctx.
+func (c *Client) RunningContext(ctx context.Context) (pb.State, error) {
+	running, err := c.client.Running(ctx, &emptypb.Empty{})
+	if err != nil {
+		return pb.State_STATE_STOPPED, err
+	}
+
+	return running.GetState(), err
+}
+
+func (c *Client) Pause() (time.Duration, error) {
+	return c.PauseContext(context.Background())
+}
+
+// PauseContext is Pause, bound by ctx.
+func (c *Client) PauseContext(ctx context.Context) (time.Duration, error) {
+	left, err := c.client.Pause(ctx, &emptypb.Empty{})
+	if err != nil {
+		return time.Duration(0), err
+	}
+
+	return left.AsDuration(), err
+}
+
+func (c *Client) Resume() (time.Time, error) {
+	return c.ResumeContext(context.Background())
+}
+
+// ResumeContext is Resume, bound by ctx.
+func (c *Client) ResumeContext(ctx context.Context) (time.Time, error) {
+	endsAt, err := c.client.Resume(ctx, &emptypb.Empty{})
+	if err != nil {
+		return time.Now(), err
+	}
+
+	return endsAt.AsTime(), err
+}
+
+func (c *Client) Phase() (*pb.PhaseResponse, error) {
+	return c.PhaseContext(context.Background())
+}
+
+// PhaseContext is Phase, bound by ctx.
+func (c *Client) PhaseContext(ctx context.Context) (*pb.PhaseResponse, error) {
+	return c.client.Phase(ctx, &emptypb.Empty{})
+}
+
+// Status returns a snapshot of the whole timer.
+func (c *Client) Status() (*pb.TomatoStatus, error) {
+	return c.StatusContext(context.Background())
+}
+
+// StatusContext is Status, bound by ctx.
+func (c *Client) StatusContext(ctx context.Context) (*pb.TomatoStatus, error) {
+	return c.client.Status(ctx, &emptypb.Empty{})
+}
+
+// Interrupt records an interruption against the current tomato, external if
+// it came from somebody or something else.
+func (c *Client) Interrupt(external bool, note string) (*pb.Interruption, error) {
+	return c.InterruptContext(context.Background(), external, note)
+}
+
+// InterruptContext is Interrupt, bound by ctx.
+func (c *Client) InterruptContext(ctx context.Context, external bool, note string) (*pb.Interruption, error) {
+	return c.client.Interrupt(ctx, &pb.InterruptRequest{External: external, Note: note})
+}
+
+// History returns the sessions started between since and until, a zero time
+// leaves that end of the range unbounded.
+func (c *Client) History(since, until time.Time) ([]*pb.Session, error) {
+	return c.HistoryContext(context.Background(), since, until)
+}
+
+// HistoryContext is History, bound by ctx.
+func (c *Client) HistoryContext(ctx context.Context, since, until time.Time) ([]*pb.Session, error) {
+	req := &pb.HistoryRequest{}
+	if !since.IsZero() {
+		req.Since = timestamppb.New(since)
+	}
+
+	if !until.IsZero() {
+		req.Until = timestamppb.New(until)
+	}
+
+	history, err := c.client.History(ctx, req)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	return history.GetSessions(), err
+}
+
+// Stats returns statistics over the sessions started between since and until,
+// a zero time leaves that end of the range unbounded.
+func (c *Client) Stats(since, until time.Time) (*pb.StatsResponse, error) {
+	return c.StatsContext(context.Background(), since, until)
+}
+
+// StatsContext is Stats, bound by ctx.
+func (c *Client) StatsContext(ctx context.Context, since, until time.Time) (*pb.StatsResponse, error) {
+	req := &pb.StatsRequest{}
+	if !since.IsZero() {
+		req.Since = timestamppb.New(since)
+	}
+
+	if !until.IsZero() {
+		req.Until = timestamppb.New(until)
+	}
+
+	return c.client.Stats(ctx, req)
+}
+
+// Watch streams timer events from the server, with ticks sent every tick
+// while the clock is running (or every second if zero). Events are delivered
+// on the returned channel until the returned function is called or the
+// stream fails, at which point the channel is closed.
+func (c *Client) Watch(tick time.Duration) (<-chan *pb.Event, func(), error) {
+	return c.WatchContext(context.Background(), tick)
+}
+
+// WatchContext is Watch, the stream also ending once ctx is done. Unlike
+// other calls it is not bound by the client's default timeout.
+func (c *Client) WatchContext(ctx context.Context, tick time.Duration) (<-chan *pb.Event, func(), error) {
+	req := &pb.WatchRequest{}
+	if tick != 0 {
+		req.TickInterval = durationpb.New(tick)
 	}
 
-	return running.GetValue(), err
+	ctx, cancel := context.WithCancel(ctx)
+
+	stream, err := c.client.Watch(ctx, req)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	events := make(chan *pb.Event)
+
+	go func() {
+		defer close(events)
+
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				return
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, cancel, nil
+}
+
+// AddTask adds a task to the todo list, expected to take estimate tomatoes.
+func (c *Client) AddTask(title string, estimate uint32) (*pb.Task, error) {
+	return c.AddTaskContext(context.Background(), title, estimate)
+}
+
+// AddTaskContext is AddTask, bound by ctx.
+func (c *Client) AddTaskContext(ctx context.Context, title string, estimate uint32) (*pb.Task, error) {
+	return c.client.AddTask(ctx, &pb.AddTaskRequest{Title: title, Estimate: estimate})
+}
+
+// ListTasks returns the tasks not yet done, or every task if all is set.
+func (c *Client) ListTasks(all bool) ([]*pb.Task, error) {
+	return c.ListTasksContext(context.Background(), all)
+}
+
+// ListTasksContext is ListTasks, bound by ctx.
+func (c *Client) ListTasksContext(ctx context.Context, all bool) ([]*pb.Task, error) {
+	tasks, err := c.client.ListTasks(ctx, &pb.ListTasksRequest{All: all})
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks.GetTasks(), err
+}
+
+func (c *Client) CompleteTask(id uint32) (*pb.Task, error) {
+	return c.CompleteTaskContext(context.Background(), id)
+}
+
+// CompleteTaskContext is CompleteTask, bound by ctx.
+func (c *Client) CompleteTaskContext(ctx context.Context, id uint32) (*pb.Task, error) {
+	return c.client.CompleteTask(ctx, &pb.CompleteTaskRequest{Id: id})
+}
+
+func (c *Client) EstimateTask(id, estimate uint32) (*pb.Task, error) {
+	return c.EstimateTaskContext(context.Background(), id, estimate)
+}
+
+// EstimateTaskContext is EstimateTask, bound by ctx.
+func (c *Client) EstimateTaskContext(ctx context.Context, id, estimate uint32) (*pb.Task, error) {
+	return c.client.EstimateTask(ctx, &pb.EstimateTaskRequest{Id: id, Estimate: estimate})
 }
@@ -0,0 +1,273 @@
+package client_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/CGA1123/tomato/client"
+	"github.com/CGA1123/tomato/pb"
+	"github.com/CGA1123/tomato/server"
+	"github.com/CGA1123/tomato/tomatotest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// hung is a server which never answers, until the call is given up on.
+type hung struct {
+	pb.UnimplementedTomatoServiceServer
+}
+
+func (hung) Status(ctx context.Context, _ *emptypb.Empty) (*pb.TomatoStatus, error) {
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+// flaky is a server which is unavailable for the first few calls.
+type flaky struct {
+	pb.UnimplementedTomatoServiceServer
+
+	failures int32
+	calls    int32
+}
+
+func (f *flaky) Status(ctx context.Context, _ *emptypb.Empty) (*pb.TomatoStatus, error) {
+	if atomic.AddInt32(&f.calls, 1) <= f.failures {
+		return nil, status.Error(codes.Unavailable, "not yet")
+	}
+
+	return &pb.TomatoStatus{State: pb.State_STATE_RUNNING}, nil
+}
+
+func newHung(t *testing.T, opts ...client.Option) *client.Client {
+	t.Helper()
+
+	return newFake(t, hung{}, opts...)
+}
+
+// newFake serves service in memory, returning a client connected to it.
+func newFake(t *testing.T, service pb.TomatoServiceServer, opts ...client.Option) *client.Client {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	pb.RegisterTomatoServiceServer(srv, service)
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	opts = append(opts, client.WithDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.Dial()
+	}))
+
+	c, err := client.New("bufconn", opts...)
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	return c
+}
+
+func TestWithTimeout(t *testing.T) {
+	c := newHung(t, client.WithTimeout(50*time.Millisecond))
+
+	began := time.Now()
+	_, err := c.Status()
+
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected the call to time out, got %v", err)
+	}
+
+	if took := time.Since(began); took > time.Second {
+		t.Fatalf("expected the call to give up after 50ms, took %v", took)
+	}
+}
+
+func TestContextDeadlineOverridesTimeout(t *testing.T) {
+	c := newHung(t, client.WithTimeout(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.StatusContext(ctx); status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected the call to time out, got %v", err)
+	}
+}
+
+func TestContextCancelled(t *testing.T) {
+	c := tomatotest.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.StartContext(ctx, 0); status.Code(err) != codes.Canceled {
+		t.Fatalf("expected the call to be cancelled, got %v", err)
+	}
+
+	state, err := c.Running()
+	if err != nil {
+		t.Fatalf("error checking running: %v", err)
+	}
+
+	if state != pb.State_STATE_STOPPED {
+		t.Fatalf("expected the cancelled start not to have happened, got %v", state)
+	}
+}
+
+func TestWatchContext(t *testing.T) {
+	c := tomatotest.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, stop, err := c.WatchContext(ctx, 0)
+	if err != nil {
+		t.Fatalf("error watching: %v", err)
+	}
+	defer stop()
+
+	cancel()
+
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("expected events to stop once the context was cancelled")
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	service := &flaky{failures: 2}
+	c := newFake(t, service, client.WithRetry(client.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}))
+
+	got, err := c.Status()
+	if err != nil {
+		t.Fatalf("expected the call to succeed once retried, got %v", err)
+	}
+
+	if got.GetState() != pb.State_STATE_RUNNING || atomic.LoadInt32(&service.calls) != 3 {
+		t.Fatalf("expected 3 calls to succeed, got %d calls and %v", service.calls, got)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	service := &flaky{failures: 5}
+	c := newFake(t, service, client.WithRetry(client.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}))
+
+	if _, err := c.Status(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected the call to fail, got %v", err)
+	}
+
+	if calls := atomic.LoadInt32(&service.calls); calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetryOnlyRetryableCodes(t *testing.T) {
+	service := &flaky{failures: 1}
+	c := newFake(t, service, client.WithRetry(client.RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+		Codes:       []codes.Code{codes.ResourceExhausted},
+	}))
+
+	if _, err := c.Status(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected the call to fail, got %v", err)
+	}
+
+	if calls := atomic.LoadInt32(&service.calls); calls != 1 {
+		t.Fatalf("expected a single attempt, got %d", calls)
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	var called []string
+	intercept := func(name string) grpc.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			called = append(called, name+" "+method)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	}
+
+	c := newFake(t, &flaky{}, client.WithUnaryInterceptor(intercept("first"), intercept("second")))
+
+	if _, err := c.Status(); err != nil {
+		t.Fatalf("error getting status: %v", err)
+	}
+
+	want := []string{"first /tomato.pb.TomatoService/Status", "second /tomato.pb.TomatoService/Status"}
+	if len(called) != 2 || called[0] != want[0] || called[1] != want[1] {
+		t.Fatalf("expected interceptors to be called as %v, got %v", want, called)
+	}
+}
+
+func TestClose(t *testing.T) {
+	c, err := tomatotest.Start(t).Dial()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	if _, err := c.Status(); err != nil {
+		t.Fatalf("error getting status: %v", err)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("error closing: %v", err)
+	}
+
+	if _, err := c.Status(); status.Code(err) != codes.Canceled {
+		t.Fatalf("expected calls to fail once closed, got %v", err)
+	}
+}
+
+func TestTargets(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "tomato.sock")
+
+	lis, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("error listening: %v", err)
+	}
+
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %v", err)
+	}
+
+	srv := grpc.NewServer()
+	tomato := server.New()
+	pb.RegisterTomatoServiceServer(srv, tomato)
+	t.Cleanup(func() {
+		srv.Stop()
+		tomato.Close()
+	})
+
+	go func() { _ = srv.Serve(lis) }()
+	go func() { _ = srv.Serve(tcp) }()
+
+	for _, target := range []string{socket, "unix://" + socket, "tcp://" + tcp.Addr().String(), "dns:///" + tcp.Addr().String()} {
+		c, err := client.New(target, client.WithTimeout(time.Second))
+		if err != nil {
+			t.Fatalf("error creating client for %v: %v", target, err)
+		}
+
+		if _, err := c.Status(); err != nil {
+			t.Errorf("error getting status from %v: %v", target, err)
+		}
+
+		c.Close()
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy describes how calls failing with a transient error are retried.
+type RetryPolicy struct {
+	// MaxAttempts is how many times a call is made before giving up,
+	// including the first.
+	MaxAttempts int
+	// Backoff is how long to wait before the first retry, doubling after
+	// each one up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Codes are the errors worth retrying, codes.Unavailable if empty. Only
+	// include codes which mean the call was not made, a retried Start or
+	// Stop may otherwise fail on finding it already happened.
+	Codes []codes.Code
+}
+
+// DefaultRetryPolicy retries calls while the server is unavailable, e.g.
+// while it is restarting, for a little over a second.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	Backoff:     100 * time.Millisecond,
+	MaxBackoff:  time.Second,
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	retryable := p.Codes
+	if len(retryable) == 0 {
+		retryable = []codes.Code{codes.Unavailable}
+	}
+
+	code := status.Code(err)
+	for _, c := range retryable {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p RetryPolicy) interceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		backoff := p.Backoff
+
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt >= p.MaxAttempts || !p.retryable(err) {
+				return err
+			}
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+
+			backoff *= 2
+			if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+				backoff = p.MaxBackoff
+			}
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Package clock abstracts telling the time and scheduling work for later, so
+// that code depending on time passing can be tested without waiting.
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and runs functions after a delay.
+type Clock interface {
+	Now() time.Time
+	// AfterFunc calls f in its own goroutine once d has elapsed.
+	AfterFunc(d time.Duration, f func()) Timer
+	// NewTicker sends the time on the ticker's channel every d, dropping
+	// ticks if the receiver falls behind.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is a pending call scheduled by Clock.AfterFunc.
+type Timer interface {
+	// Stop prevents the call from happening, returning false if it has
+	// already happened or been stopped.
+	Stop() bool
+}
+
+// Ticker delivers ticks created by Clock.NewTicker.
+type Ticker interface {
+	C() <-chan time.Time
+	// Stop turns off the ticker, after which no more ticks are sent.
+	Stop()
+}
+
+// Real is the system clock.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// Fake is a Clock which only moves when told to, calling any functions which
+// become due as it does.
+type Fake struct {
+	mut    sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFake returns a Fake clock stopped at now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	return f.now
+}
+
+// AfterFunc schedules fn to be called once the clock has been advanced by d.
+// Unlike time.AfterFunc, fn is called synchronously by Advance.
+func (f *Fake) AfterFunc(d time.Duration, fn func()) Timer {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	timer := &fakeTimer{clock: f, at: f.now.Add(d), f: fn}
+	f.timers = append(f.timers, timer)
+
+	return timer
+}
+
+// NewTicker returns a Ticker which ticks each time the clock is advanced past
+// another multiple of d.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+
+	ticker := &fakeTicker{clock: f, d: d, c: make(chan time.Time, 1)}
+	ticker.schedule()
+
+	return ticker
+}
+
+// Advance moves the clock forward by d, calling every function which becomes
+// due along the way in the order they are due. The clock reads the time each
+// function was due while it is called, so functions scheduling further calls
+// see time pass as it would have.
+func (f *Fake) Advance(d time.Duration) {
+	f.mut.Lock()
+	end := f.now.Add(d)
+
+	for {
+		sort.SliceStable(f.timers, func(i, j int) bool {
+			return f.timers[i].at.Before(f.timers[j].at)
+		})
+
+		if len(f.timers) == 0 || f.timers[0].at.After(end) {
+			break
+		}
+
+		timer := f.timers[0]
+		f.timers = f.timers[1:]
+		if timer.at.After(f.now) {
+			f.now = timer.at
+		}
+
+		f.mut.Unlock()
+		timer.f()
+		f.mut.Lock()
+	}
+
+	f.now = end
+	f.mut.Unlock()
+}
+
+// Pending returns how many calls are waiting for the clock to advance.
+func (f *Fake) Pending() int {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	return len(f.timers)
+}
+
+type fakeTimer struct {
+	clock *Fake
+	at    time.Time
+	f     func()
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mut.Lock()
+	defer t.clock.mut.Unlock()
+
+	for i, timer := range t.clock.timers {
+		if timer == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+type fakeTicker struct {
+	clock *Fake
+	d     time.Duration
+	c     chan time.Time
+
+	mut     sync.Mutex
+	timer   Timer
+	stopped bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	t.stopped = true
+	t.timer.Stop()
+}
+
+func (t *fakeTicker) schedule() {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	if !t.stopped {
+		t.timer = t.clock.AfterFunc(t.d, t.tick)
+	}
+}
+
+func (t *fakeTicker) tick() {
+	select {
+	case t.c <- t.clock.Now():
+	default:
+	}
+
+	t.schedule()
+}
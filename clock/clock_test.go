@@ -0,0 +1,118 @@
+package clock_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CGA1123/tomato/clock"
+)
+
+var epoch = time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC)
+
+func TestFakeAdvance(t *testing.T) {
+	fake := clock.NewFake(epoch)
+
+	var fired []time.Duration
+	for _, d := range []time.Duration{3 * time.Minute, time.Minute, 2 * time.Minute} {
+		d := d
+		fake.AfterFunc(d, func() {
+			if got := fake.Now().Sub(epoch); got != d {
+				t.Errorf("expected the clock to read %v when called, got %v", d, got)
+			}
+
+			fired = append(fired, d)
+		})
+	}
+
+	fake.Advance(2 * time.Minute)
+	if want := []time.Duration{time.Minute, 2 * time.Minute}; !reflect.DeepEqual(fired, want) {
+		t.Fatalf("expected %v to have fired, got %v", want, fired)
+	}
+
+	if got := fake.Now(); !got.Equal(epoch.Add(2 * time.Minute)) {
+		t.Fatalf("expected the clock to have advanced 2m, got %v", got.Sub(epoch))
+	}
+
+	if fake.Pending() != 1 {
+		t.Fatalf("expected 1 pending call, got %d", fake.Pending())
+	}
+
+	fake.Advance(time.Hour)
+	if len(fired) != 3 || fake.Pending() != 0 {
+		t.Fatalf("expected every call to have fired, got %v", fired)
+	}
+}
+
+func TestFakeStop(t *testing.T) {
+	fake := clock.NewFake(epoch)
+
+	called := false
+	timer := fake.AfterFunc(time.Minute, func() { called = true })
+
+	if !timer.Stop() {
+		t.Fatalf("expected stopping a pending call to succeed")
+	}
+
+	if timer.Stop() {
+		t.Fatalf("expected stopping a call twice to fail")
+	}
+
+	fake.Advance(time.Hour)
+	if called {
+		t.Fatalf("expected a stopped call not to happen")
+	}
+}
+
+func TestFakeReschedule(t *testing.T) {
+	fake := clock.NewFake(epoch)
+
+	calls := 0
+	var tick func()
+	tick = func() {
+		calls++
+		fake.AfterFunc(time.Minute, tick)
+	}
+
+	fake.AfterFunc(time.Minute, tick)
+	fake.Advance(5 * time.Minute)
+
+	if calls != 5 {
+		t.Fatalf("expected 5 calls within 5m, got %d", calls)
+	}
+}
+
+func TestFakeTicker(t *testing.T) {
+	fake := clock.NewFake(epoch)
+	ticker := fake.NewTicker(time.Minute)
+
+	select {
+	case tick := <-ticker.C():
+		t.Fatalf("expected no tick before the clock advances, got %v", tick)
+	default:
+	}
+
+	fake.Advance(90 * time.Second)
+	if tick := <-ticker.C(); !tick.Equal(epoch.Add(time.Minute)) {
+		t.Fatalf("expected a tick at 1m, got %v", tick.Sub(epoch))
+	}
+
+	// Ticks are dropped while the receiver is behind, as with time.Ticker.
+	fake.Advance(5 * time.Minute)
+	if tick := <-ticker.C(); !tick.Equal(epoch.Add(2 * time.Minute)) {
+		t.Fatalf("expected the first missed tick at 2m, got %v", tick.Sub(epoch))
+	}
+
+	ticker.Stop()
+	fake.Advance(time.Hour)
+
+	select {
+	case tick := <-ticker.C():
+		t.Fatalf("expected no tick once stopped, got %v", tick.Sub(epoch))
+	default:
+	}
+
+	if fake.Pending() != 0 {
+		t.Fatalf("expected a stopped ticker to leave nothing pending, got %d", fake.Pending())
+	}
+}
@@ -0,0 +1,228 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config holds the settings shared by the tomato server and client commands.
+type Config struct {
+	Socket      string `toml:"socket"`
+	PidFile     string `toml:"pid_file"`
+	LogFile     string `toml:"log_file"`
+	LogPrefix   string `toml:"log_prefix"`
+	HistoryFile string `toml:"history_file"`
+	StateFile   string `toml:"state_file"`
+	TasksFile   string `toml:"tasks_file"`
+	Quiet       bool   `toml:"quiet"`
+	// Autostart launches the server in the background when a client command
+	// finds it is not running.
+	Autostart bool `toml:"autostart"`
+	// Timeout is how long client commands wait for each call to the server,
+	// zero to wait forever.
+	Timeout Duration `toml:"timeout"`
+	// Retry retries calls from client commands while the server is
+	// unavailable, e.g. restarting.
+	Retry bool `toml:"retry"`
+	// HTTP is where the server serves its JSON API, "socket" to share its
+	// unix socket or a unix:///path or tcp://host:port address.
+	HTTP string `toml:"http"`
+	// Listen is a tcp://host:port address the server also listens on, over
+	// TLS and requiring Token.
+	Listen  string `toml:"listen"`
+	TLSCert string `toml:"tls_cert"`
+	TLSKey  string `toml:"tls_key"`
+	Token   string `toml:"token"`
+	// Addr is a tcp://host:port address of a remote server for client
+	// commands to connect to, trusting the certificate in CAFile.
+	Addr   string `toml:"addr"`
+	CAFile string `toml:"ca_file"`
+
+	Timer  Timer  `toml:"timer"`
+	Notify Notify `toml:"notify"`
+	Hooks  Hooks  `toml:"hooks"`
+}
+
+// Timer configures the length of each phase of the cycle.
+type Timer struct {
+	Duration       Duration `toml:"duration"`
+	ShortBreak     Duration `toml:"short_break"`
+	LongBreak      Duration `toml:"long_break"`
+	LongBreakEvery int      `toml:"long_break_every"`
+}
+
+// Notify configures desktop notifications.
+type Notify struct {
+	Enabled     bool   `toml:"enabled"`
+	Title       string `toml:"title"`
+	Body        string `toml:"body"`
+	DBusAddress string `toml:"dbus_address"`
+}
+
+// Hooks configures the commands to run on each event.
+type Hooks struct {
+	Timeout   Duration `toml:"timeout"`
+	Started   []string `toml:"started"`
+	Stopped   []string `toml:"stopped"`
+	Completed []string `toml:"completed"`
+	Break     []string `toml:"break"`
+	Paused    []string `toml:"paused"`
+	Resumed   []string `toml:"resumed"`
+}
+
+// Commands returns the configured commands keyed by event name.
+func (h Hooks) Commands() map[string][]string {
+	return map[string][]string{
+		"started":   h.Started,
+		"stopped":   h.Stopped,
+		"completed": h.Completed,
+		"break":     h.Break,
+		"paused":    h.Paused,
+		"resumed":   h.Resumed,
+	}
+}
+
+// Duration is a time.Duration written as a string in the config file, e.g.
+// "25m".
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+
+	d.Duration = parsed
+
+	return nil
+}
+
+// Path returns the location of the config file, $TOMATO_CONFIG if set or
+// $XDG_CONFIG_HOME/tomato/config.toml otherwise.
+func Path() string {
+	if path := os.Getenv("TOMATO_CONFIG"); path != "" {
+		return path
+	}
+
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+
+		dir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(dir, "tomato", "config.toml")
+}
+
+// Load overrides the settings in cfg with those in the config file at path,
+// if it exists, and then with any set through TOMATO_* environment variables.
+func Load(path string, cfg *Config) error {
+	if path != "" {
+		md, err := toml.DecodeFile(path, cfg)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error reading config file %v: %w", path, err)
+		}
+
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("unknown keys in config file %v: %v", path, undecoded)
+		}
+	}
+
+	return loadEnv(cfg)
+}
+
+func loadEnv(cfg *Config) error {
+	vars := []struct {
+		name string
+		set  func(string) error
+	}{
+		{"TOMATO_SOCKET", setString(&cfg.Socket)},
+		{"TOMATO_PID_FILE", setString(&cfg.PidFile)},
+		{"TOMATO_LOG_FILE", setString(&cfg.LogFile)},
+		{"TOMATO_LOG_PREFIX", setString(&cfg.LogPrefix)},
+		{"TOMATO_HISTORY_FILE", setString(&cfg.HistoryFile)},
+		{"TOMATO_STATE_FILE", setString(&cfg.StateFile)},
+		{"TOMATO_TASKS_FILE", setString(&cfg.TasksFile)},
+		{"TOMATO_QUIET", setBool(&cfg.Quiet)},
+		{"TOMATO_AUTOSTART", setBool(&cfg.Autostart)},
+		{"TOMATO_TIMEOUT", setDuration(&cfg.Timeout)},
+		{"TOMATO_RETRY", setBool(&cfg.Retry)},
+		{"TOMATO_HTTP", setString(&cfg.HTTP)},
+		{"TOMATO_LISTEN", setString(&cfg.Listen)},
+		{"TOMATO_TLS_CERT", setString(&cfg.TLSCert)},
+		{"TOMATO_TLS_KEY", setString(&cfg.TLSKey)},
+		{"TOMATO_TOKEN", setString(&cfg.Token)},
+		{"TOMATO_ADDR", setString(&cfg.Addr)},
+		{"TOMATO_CA_FILE", setString(&cfg.CAFile)},
+		{"TOMATO_DURATION", setDuration(&cfg.Timer.Duration)},
+		{"TOMATO_SHORT_BREAK", setDuration(&cfg.Timer.ShortBreak)},
+		{"TOMATO_LONG_BREAK", setDuration(&cfg.Timer.LongBreak)},
+		{"TOMATO_LONG_BREAK_EVERY", setInt(&cfg.Timer.LongBreakEvery)},
+		{"TOMATO_NOTIFY", setBool(&cfg.Notify.Enabled)},
+		{"TOMATO_NOTIFY_TITLE", setString(&cfg.Notify.Title)},
+		{"TOMATO_NOTIFY_BODY", setString(&cfg.Notify.Body)},
+		{"TOMATO_DBUS_ADDRESS", setString(&cfg.Notify.DBusAddress)},
+		{"TOMATO_HOOK_TIMEOUT", setDuration(&cfg.Hooks.Timeout)},
+	}
+
+	for _, v := range vars {
+		value, ok := os.LookupEnv(v.name)
+		if !ok {
+			continue
+		}
+
+		if err := v.set(strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("invalid value for %v: %w", v.name, err)
+		}
+	}
+
+	return nil
+}
+
+func setString(dst *string) func(string) error {
+	return func(value string) error {
+		*dst = value
+		return nil
+	}
+}
+
+func setBool(dst *bool) func(string) error {
+	return func(value string) error {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+
+		*dst = parsed
+		return nil
+	}
+}
+
+func setInt(dst *int) func(string) error {
+	return func(value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+
+		*dst = parsed
+		return nil
+	}
+}
+
+func setDuration(dst *Duration) func(string) error {
+	return func(value string) error {
+		return dst.UnmarshalText([]byte(value))
+	}
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+)
+
+// RuntimeDir returns the directory for files which only live as long as the
+// server, such as its socket and pidfile. This is $XDG_RUNTIME_DIR/tomato,
+// falling back to a per-user directory under the system temporary directory.
+func RuntimeDir() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "tomato")
+	}
+
+	return filepath.Join(os.TempDir(), "tomato-"+strconv.Itoa(os.Getuid()))
+}
+
+// StateDir returns the directory for files which persist across restarts,
+// such as logs and history. This is $XDG_STATE_HOME/tomato, falling back to
+// ~/.local/state/tomato.
+func StateDir() string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "tomato")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return RuntimeDir()
+	}
+
+	return filepath.Join(home, ".local", "state", "tomato")
+}
+
+// EnsureDir creates dir, and any missing parents, accessible only by the
+// current user.
+func EnsureDir(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("error creating %v: %w", dir, err)
+	}
+
+	return nil
+}
+
+// CheckPrivate returns an error unless dir is owned by, and only accessible
+// to, the current user. This guards against another user on a shared machine
+// creating the directory first in order to hijack the server.
+func CheckPrivate(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("refusing to use %v: it is owned by another user", dir)
+	}
+
+	if info.Mode().Perm()&0077 != 0 {
+		return fmt.Errorf("refusing to use %v: it is accessible by other users (mode %v)", dir, info.Mode().Perm())
+	}
+
+	return nil
+}
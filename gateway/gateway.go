@@ -0,0 +1,280 @@
+// Package gateway serves the tomato service as a JSON API over HTTP, for
+// clients which cannot speak gRPC.
+package gateway
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/CGA1123/tomato/pb"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Gateway translates HTTP requests into calls on a TomatoServiceServer.
+type Gateway struct {
+	tomato pb.TomatoServiceServer
+	mux    *http.ServeMux
+}
+
+// New returns a Gateway serving the routes documented in OpenAPI on top of
+// tomato.
+func New(tomato pb.TomatoServiceServer) *Gateway {
+	g := &Gateway{tomato: tomato, mux: http.NewServeMux()}
+
+	g.handle("/v1/start", http.MethodPost, g.start)
+	g.handle("/v1/stop", http.MethodPost, g.stop)
+	g.handle("/v1/pause", http.MethodPost, g.pause)
+	g.handle("/v1/resume", http.MethodPost, g.resume)
+	g.handle("/v1/status", http.MethodGet, g.status)
+	g.handle("/v1/history", http.MethodGet, g.history)
+	g.handle("/v1/stats", http.MethodGet, g.stats)
+	g.handle("/v1/openapi.json", http.MethodGet, g.openapi)
+
+	return g
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// RequireToken wraps h, rejecting any request which does not carry token as a
+// bearer token in its Authorization header.
+func RequireToken(token string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		given := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			write(w, http.StatusUnauthorized, fields{"error": "invalid or missing token"})
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// handle routes requests for path to f, rejecting any other method and
+// writing out whatever f returns.
+func (g *Gateway) handle(path, method string, f func(*http.Request) (interface{}, error)) {
+	g.mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			write(w, http.StatusMethodNotAllowed, fields{"error": fmt.Sprintf("%v requires %v", path, method)})
+			return
+		}
+
+		body, err := f(r)
+		if err != nil {
+			write(w, statusCode(err), fields{"error": status.Convert(err).Message()})
+			return
+		}
+
+		write(w, http.StatusOK, body)
+	})
+}
+
+func (g *Gateway) start(r *http.Request) (interface{}, error) {
+	req := &pb.StartRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	ends, err := g.tomato.Start(r.Context(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields{"endsAt": ends}, nil
+}
+
+func (g *Gateway) stop(r *http.Request) (interface{}, error) {
+	req := &pb.StopRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	left, err := g.tomato.Stop(r.Context(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields{"remaining": left}, nil
+}
+
+func (g *Gateway) pause(r *http.Request) (interface{}, error) {
+	left, err := g.tomato.Pause(r.Context(), &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return fields{"remaining": left}, nil
+}
+
+func (g *Gateway) resume(r *http.Request) (interface{}, error) {
+	ends, err := g.tomato.Resume(r.Context(), &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return fields{"endsAt": ends}, nil
+}
+
+func (g *Gateway) status(r *http.Request) (interface{}, error) {
+	return g.tomato.Status(r.Context(), &emptypb.Empty{})
+}
+
+func (g *Gateway) history(r *http.Request) (interface{}, error) {
+	since, until, err := timeRange(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.tomato.History(r.Context(), &pb.HistoryRequest{Since: since, Until: until})
+}
+
+func (g *Gateway) stats(r *http.Request) (interface{}, error) {
+	since, until, err := timeRange(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.tomato.Stats(r.Context(), &pb.StatsRequest{Since: since, Until: until})
+}
+
+func (g *Gateway) openapi(r *http.Request) (interface{}, error) {
+	return json.RawMessage(OpenAPI), nil
+}
+
+// decode reads the JSON body of r into msg, an empty body leaves msg unset.
+func decode(r *http.Request, msg proto.Message) error {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "error reading body: %v", err)
+	}
+
+	if len(body) == 0 {
+		return nil
+	}
+
+	if err := protojson.Unmarshal(body, msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid body: %v", err)
+	}
+
+	return nil
+}
+
+// timeRange parses the optional RFC 3339 since and until query parameters.
+func timeRange(r *http.Request) (*timestamppb.Timestamp, *timestamppb.Timestamp, error) {
+	query := r.URL.Query()
+	parsed := make([]*timestamppb.Timestamp, 2)
+
+	for i, name := range []string{"since", "until"} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "invalid %v: %v", name, err)
+		}
+
+		parsed[i] = timestamppb.New(t)
+	}
+
+	return parsed[0], parsed[1], nil
+}
+
+// statusCode maps an error returned by the service onto an HTTP status. The
+// service refuses requests it cannot fulfil, such as pausing a stopped timer,
+// with InvalidArgument or FailedPrecondition and these are reported as bad
+// requests. Anything else, e.g. failing to read history, is the server's
+// fault.
+func statusCode(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// fields is a JSON object whose proto.Message values are encoded with
+// protojson.
+type fields map[string]interface{}
+
+func (f fields) MarshalJSON() ([]byte, error) {
+	encoded := make(map[string]json.RawMessage, len(f))
+
+	for name, value := range f {
+		data, err := marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded[name] = data
+	}
+
+	return json.Marshal(encoded)
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	if msg, ok := v.(proto.Message); ok {
+		data, err := protojson.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		// protojson deliberately varies its whitespace, compact it so that
+		// responses are stable.
+		buf := &bytes.Buffer{}
+		if err := json.Compact(buf, data); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+
+	return json.Marshal(v)
+}
+
+func write(w http.ResponseWriter, code int, body interface{}) {
+	data, err := marshal(body)
+	if err != nil {
+		code = http.StatusInternalServerError
+		data = []byte(`{"error":"error encoding response"}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(data)
+	w.Write([]byte("\n"))
+}
+
+// WithGRPC serves gRPC requests with grpcServer and everything else with h,
+// over cleartext HTTP/2 as well as HTTP/1, so that both can share a listener.
+func WithGRPC(grpcServer http.Handler, h http.Handler) http.Handler {
+	return h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	}), &http2.Server{})
+}
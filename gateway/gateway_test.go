@@ -0,0 +1,238 @@
+package gateway_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CGA1123/tomato/client"
+	"github.com/CGA1123/tomato/clock"
+	"github.com/CGA1123/tomato/gateway"
+	"github.com/CGA1123/tomato/pb"
+	"github.com/CGA1123/tomato/server"
+	"google.golang.org/grpc"
+)
+
+var epoch = time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC)
+
+// newGateway returns a gateway in front of a server, with history, whose
+// clock only moves when advanced.
+func newGateway(t *testing.T) (*server.Server, *gateway.Gateway, *clock.Fake) {
+	t.Helper()
+
+	fake := clock.NewFake(epoch)
+	s := server.New(
+		server.WithClock(fake),
+		server.WithHistory(server.NewHistory(filepath.Join(t.TempDir(), "history"))),
+	)
+	t.Cleanup(s.Close)
+
+	return s, gateway.New(s), fake
+}
+
+// do makes a request to h, decoding the JSON response into an object.
+func do(t *testing.T, h http.Handler, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Fatalf("expected %v %v to respond with JSON, got %q", method, path, got)
+	}
+
+	response := map[string]interface{}{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("error decoding %v %v response %q: %v", method, path, w.Body.String(), err)
+	}
+
+	return w.Code, response
+}
+
+func expect(t *testing.T, h http.Handler, method, path, body string, code int) map[string]interface{} {
+	t.Helper()
+
+	got, response := do(t, h, method, path, body)
+	if got != code {
+		t.Fatalf("expected %v %v to return %d, got %d: %v", method, path, code, got, response)
+	}
+
+	return response
+}
+
+func TestRoutes(t *testing.T) {
+	_, g, fake := newGateway(t)
+
+	started := expect(t, g, http.MethodPost, "/v1/start", `{"duration": "600s", "label": "gateway"}`, http.StatusOK)
+	if want := epoch.Add(10 * time.Minute).Format(time.RFC3339); started["endsAt"] != want {
+		t.Fatalf("expected the tomato to end at %v, got %v", want, started["endsAt"])
+	}
+
+	expect(t, g, http.MethodPost, "/v1/start", "", http.StatusBadRequest)
+
+	fake.Advance(4 * time.Minute)
+
+	status := expect(t, g, http.MethodGet, "/v1/status", "", http.StatusOK)
+	if status["state"] != "STATE_RUNNING" || status["label"] != "gateway" || status["remaining"] != "360s" {
+		t.Fatalf("expected a running tomato with 6m left, got %v", status)
+	}
+
+	paused := expect(t, g, http.MethodPost, "/v1/pause", "", http.StatusOK)
+	if paused["remaining"] != "360s" {
+		t.Fatalf("expected 6m left when paused, got %v", paused)
+	}
+
+	fake.Advance(time.Hour)
+
+	resumed := expect(t, g, http.MethodPost, "/v1/resume", "", http.StatusOK)
+	if want := fake.Now().Add(6 * time.Minute).Format(time.RFC3339); resumed["endsAt"] != want {
+		t.Fatalf("expected the tomato to end at %v once resumed, got %v", want, resumed["endsAt"])
+	}
+
+	stopped := expect(t, g, http.MethodPost, "/v1/stop", `{"reason": "lunch"}`, http.StatusOK)
+	if stopped["remaining"] != "360s" {
+		t.Fatalf("expected 6m left when stopped, got %v", stopped)
+	}
+
+	// Refused by the service rather than the gateway.
+	expect(t, g, http.MethodPost, "/v1/pause", "", http.StatusBadRequest)
+
+	history := expect(t, g, http.MethodGet, "/v1/history?since="+epoch.Format(time.RFC3339), "", http.StatusOK)
+	sessions, _ := history["sessions"].([]interface{})
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %v", history)
+	}
+
+	if session := sessions[0].(map[string]interface{}); session["reason"] != "lunch" || session["outcome"] != "OUTCOME_STOPPED" {
+		t.Fatalf("expected a tomato stopped for lunch, got %v", session)
+	}
+
+	if history := expect(t, g, http.MethodGet, "/v1/history?until="+epoch.Format(time.RFC3339), "", http.StatusOK); len(history) != 0 {
+		t.Fatalf("expected no sessions before the first, got %v", history)
+	}
+
+	stats := expect(t, g, http.MethodGet, "/v1/stats", "", http.StatusOK)
+	if stats["stopped"] != float64(1) {
+		t.Fatalf("expected 1 stopped tomato, got %v", stats)
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+	_, g, _ := newGateway(t)
+
+	for _, path := range []string{"/v1/start", "/v1/stop", "/v1/pause", "/v1/resume"} {
+		w := httptest.NewRecorder()
+		g.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != http.MethodPost {
+			t.Errorf("expected GET %v to be refused allowing POST, got %d allowing %q", path, w.Code, w.Header().Get("Allow"))
+		}
+	}
+
+	for _, path := range []string{"/v1/status", "/v1/history", "/v1/stats", "/v1/openapi.json"} {
+		w := httptest.NewRecorder()
+		g.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+
+		if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != http.MethodGet {
+			t.Errorf("expected POST %v to be refused allowing GET, got %d allowing %q", path, w.Code, w.Header().Get("Allow"))
+		}
+	}
+
+	expect(t, g, http.MethodGet, "/v1/history?since=yesterday", "", http.StatusBadRequest)
+	expect(t, g, http.MethodGet, "/v1/stats?until=2021-06-01", "", http.StatusBadRequest)
+	expect(t, g, http.MethodPost, "/v1/start", `{"duration": 600}`, http.StatusBadRequest)
+	expect(t, g, http.MethodPost, "/v1/start", `{"duration": "1s"}`, http.StatusBadRequest)
+}
+
+func TestServerErrors(t *testing.T) {
+	// History which can't be read is the server's fault, not the request's.
+	s := server.New(server.WithHistory(server.NewHistory(t.TempDir())))
+	t.Cleanup(s.Close)
+
+	g := gateway.New(s)
+	expect(t, g, http.MethodGet, "/v1/history", "", http.StatusInternalServerError)
+	expect(t, g, http.MethodGet, "/v1/stats", "", http.StatusInternalServerError)
+}
+
+func TestOpenAPI(t *testing.T) {
+	if !json.Valid([]byte(gateway.OpenAPI)) {
+		t.Fatalf("expected OpenAPI to be valid JSON")
+	}
+
+	_, g, _ := newGateway(t)
+
+	document := expect(t, g, http.MethodGet, "/v1/openapi.json", "", http.StatusOK)
+	paths, _ := document["paths"].(map[string]interface{})
+
+	for _, path := range []string{"/v1/start", "/v1/stop", "/v1/pause", "/v1/resume", "/v1/status", "/v1/history", "/v1/stats"} {
+		if _, ok := paths[path]; !ok {
+			t.Errorf("expected %v to be documented", path)
+		}
+	}
+}
+
+func TestRequireToken(t *testing.T) {
+	_, g, _ := newGateway(t)
+	h := gateway.RequireToken("s3cret", g)
+
+	for _, header := range []string{"", "Bearer guess", "s3cret!", "Basic s3cret"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/v1/status", nil)
+		if header != "" {
+			r.Header.Set("Authorization", header)
+		}
+
+		h.ServeHTTP(w, r)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("expected Authorization %q to be refused, got %d", header, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/v1/status", nil)
+	r.Header.Set("Authorization", "Bearer s3cret")
+
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the right token to be accepted, got %d", w.Code)
+	}
+}
+
+func TestWithGRPC(t *testing.T) {
+	s, g, _ := newGateway(t)
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterTomatoServiceServer(grpcServer, s)
+
+	srv := httptest.NewServer(gateway.WithGRPC(grpcServer, g))
+	t.Cleanup(srv.Close)
+
+	c, err := client.New("tcp://"+srv.Listener.Addr().String(), client.WithTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	defer c.Close()
+
+	if _, err := c.StartWith(client.StartOptions{Label: "shared"}); err != nil {
+		t.Fatalf("error starting over gRPC: %v", err)
+	}
+
+	resp, err := http.Get(srv.URL + "/v1/status")
+	if err != nil {
+		t.Fatalf("error getting status over HTTP: %v", err)
+	}
+	defer resp.Body.Close()
+
+	status := map[string]interface{}{}
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		t.Fatalf("error decoding status: %v", err)
+	}
+
+	if status["label"] != "shared" {
+		t.Fatalf("expected the tomato started over gRPC, got %v", status)
+	}
+}
@@ -0,0 +1,268 @@
+package gateway
+
+// OpenAPI describes the routes served by a Gateway, it is served at
+// /v1/openapi.json.
+const OpenAPI = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "tomato",
+    "description": "A tomato timer, durations are strings of seconds (e.g. \"1500s\") and times are RFC 3339.",
+    "version": "1"
+  },
+  "paths": {
+    "/v1/start": {
+      "post": {
+        "summary": "Start a tomato, cutting any break short.",
+        "requestBody": {
+          "required": false,
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "properties": {
+                  "duration": {"$ref": "#/components/schemas/Duration"},
+                  "label": {"type": "string"},
+                  "tags": {"$ref": "#/components/schemas/Tags"},
+                  "task": {"type": "integer", "description": "The id of a task to count the tomato against."}
+                }
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {"$ref": "#/components/responses/EndsAt"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/stop": {
+      "post": {
+        "summary": "Stop the current tomato or break.",
+        "requestBody": {
+          "required": false,
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "properties": {
+                  "reason": {"type": "string"}
+                }
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {"$ref": "#/components/responses/Remaining"},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/pause": {
+      "post": {
+        "summary": "Pause the clock.",
+        "responses": {
+          "200": {"$ref": "#/components/responses/Remaining"},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/resume": {
+      "post": {
+        "summary": "Resume a paused clock.",
+        "responses": {
+          "200": {"$ref": "#/components/responses/EndsAt"},
+          "400": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/status": {
+      "get": {
+        "summary": "The current state of the timer.",
+        "responses": {
+          "200": {
+            "description": "The timer.",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "state": {"type": "string", "enum": ["STATE_STOPPED", "STATE_RUNNING", "STATE_PAUSED"]},
+                    "phase": {"$ref": "#/components/schemas/Phase"},
+                    "startedAt": {"type": "string", "format": "date-time"},
+                    "endsAt": {"type": "string", "format": "date-time"},
+                    "remaining": {"$ref": "#/components/schemas/Duration"},
+                    "pausedAt": {"type": "string", "format": "date-time"},
+                    "label": {"type": "string"},
+                    "cycle": {"type": "integer"},
+                    "longBreakEvery": {"type": "integer"},
+                    "tags": {"$ref": "#/components/schemas/Tags"},
+                    "task": {"type": "integer"},
+                    "interruptions": {"type": "integer"}
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/v1/history": {
+      "get": {
+        "summary": "Sessions started within a range.",
+        "parameters": [
+          {"$ref": "#/components/parameters/Since"},
+          {"$ref": "#/components/parameters/Until"}
+        ],
+        "responses": {
+          "200": {
+            "description": "The sessions, oldest first.",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "sessions": {"type": "array", "items": {"$ref": "#/components/schemas/Session"}}
+                  }
+                }
+              }
+            }
+          },
+          "400": {"$ref": "#/components/responses/Error"},
+          "500": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/stats": {
+      "get": {
+        "summary": "Statistics over the tomatoes started within a range.",
+        "parameters": [
+          {"$ref": "#/components/parameters/Since"},
+          {"$ref": "#/components/parameters/Until"}
+        ],
+        "responses": {
+          "200": {
+            "description": "The statistics.",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "completed": {"type": "integer"},
+                    "stopped": {"type": "integer"},
+                    "abandoned": {"type": "integer"},
+                    "focused": {"$ref": "#/components/schemas/Duration"},
+                    "completionRate": {"type": "number"},
+                    "currentStreak": {"type": "integer"},
+                    "longestStreak": {"type": "integer"},
+                    "internalInterruptions": {"type": "integer"},
+                    "externalInterruptions": {"type": "integer"},
+                    "days": {
+                      "type": "array",
+                      "items": {
+                        "type": "object",
+                        "properties": {
+                          "date": {"type": "string", "format": "date"},
+                          "completed": {"type": "integer"},
+                          "focused": {"$ref": "#/components/schemas/Duration"}
+                        }
+                      }
+                    },
+                    "tags": {
+                      "type": "array",
+                      "items": {
+                        "type": "object",
+                        "properties": {
+                          "tag": {"type": "string"},
+                          "completed": {"type": "integer"},
+                          "focused": {"$ref": "#/components/schemas/Duration"}
+                        }
+                      }
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "400": {"$ref": "#/components/responses/Error"},
+          "500": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/openapi.json": {
+      "get": {
+        "summary": "This document.",
+        "responses": {
+          "200": {"description": "The OpenAPI document."}
+        }
+      }
+    }
+  },
+  "components": {
+    "securitySchemes": {
+      "bearer": {"type": "http", "scheme": "bearer", "description": "Required when served over TCP with a token."}
+    },
+    "schemas": {
+      "Duration": {"type": "string", "example": "1500s"},
+      "Tags": {"type": "array", "items": {"type": "string"}},
+      "Phase": {"type": "string", "enum": ["PHASE_IDLE", "PHASE_WORK", "PHASE_SHORT_BREAK", "PHASE_LONG_BREAK"]},
+      "Session": {
+        "type": "object",
+        "properties": {
+          "phase": {"$ref": "#/components/schemas/Phase"},
+          "outcome": {"type": "string", "enum": ["OUTCOME_COMPLETED", "OUTCOME_STOPPED", "OUTCOME_ABANDONED", "OUTCOME_SKIPPED"]},
+          "startedAt": {"type": "string", "format": "date-time"},
+          "endedAt": {"type": "string", "format": "date-time"},
+          "planned": {"$ref": "#/components/schemas/Duration"},
+          "actual": {"$ref": "#/components/schemas/Duration"},
+          "label": {"type": "string"},
+          "tags": {"$ref": "#/components/schemas/Tags"},
+          "task": {"type": "integer"},
+          "reason": {"type": "string"},
+          "interruptions": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "properties": {
+                "kind": {"type": "string", "enum": ["INTERRUPTION_INTERNAL", "INTERRUPTION_EXTERNAL"]},
+                "at": {"type": "string", "format": "date-time"},
+                "note": {"type": "string"}
+              }
+            }
+          }
+        }
+      }
+    },
+    "parameters": {
+      "Since": {"name": "since", "in": "query", "schema": {"type": "string", "format": "date-time"}},
+      "Until": {"name": "until", "in": "query", "schema": {"type": "string", "format": "date-time"}}
+    },
+    "responses": {
+      "EndsAt": {
+        "description": "When the clock will run out.",
+        "content": {
+          "application/json": {
+            "schema": {"type": "object", "properties": {"endsAt": {"type": "string", "format": "date-time"}}}
+          }
+        }
+      },
+      "Remaining": {
+        "description": "How long was left on the clock.",
+        "content": {
+          "application/json": {
+            "schema": {"type": "object", "properties": {"remaining": {"$ref": "#/components/schemas/Duration"}}}
+          }
+        }
+      },
+      "Error": {
+        "description": "The request could not be fulfilled.",
+        "content": {
+          "application/json": {
+            "schema": {"type": "object", "properties": {"error": {"type": "string"}}}
+          }
+        }
+      }
+    }
+  },
+  "security": [{}, {"bearer": []}]
+}`
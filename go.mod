@@ -3,9 +3,11 @@ module github.com/CGA1123/tomato
 go 1.15
 
 require (
+	github.com/BurntSushi/toml v0.4.1
+	github.com/godbus/dbus/v5 v5.1.0
 	github.com/soellman/pidfile v0.0.0-20160225184504-d482c905736b
-	github.com/spf13/cobra v1.1.3 // indirect
-	golang.org/x/net v0.0.0-20210525063256-abc453219eb5 // indirect
+	github.com/spf13/cobra v1.1.3
+	golang.org/x/net v0.0.0-20210525063256-abc453219eb5
 	golang.org/x/sys v0.0.0-20210525143221-35b2ab0089ea // indirect
 	google.golang.org/genproto v0.0.0-20210524171403-669157292da3 // indirect
 	google.golang.org/grpc v1.38.0
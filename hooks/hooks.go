@@ -0,0 +1,204 @@
+package hooks
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/CGA1123/tomato/pb"
+)
+
+// Events which hooks can be registered for.
+const (
+	Started   = "started"
+	Stopped   = "stopped"
+	Completed = "completed"
+	Break     = "break"
+	Paused    = "paused"
+	Resumed   = "resumed"
+)
+
+var (
+	Events  = []string{Started, Stopped, Completed, Break, Paused, Resumed}
+	Timeout = 10 * time.Second
+)
+
+// Hooks runs user configured shell commands in response to timer events.
+// Details of the event are passed to commands through the TOMATO_EVENT,
+// TOMATO_PHASE, TOMATO_ENDS_AT, TOMATO_REMAINING, TOMATO_OUTCOME and
+// TOMATO_REASON environment variables.
+type Hooks struct {
+	commands map[string][]string
+	timeout  time.Duration
+}
+
+// New returns Hooks running commands, keyed by event name, which are killed
+// if they take longer than timeout.
+func New(commands map[string][]string, timeout time.Duration) (*Hooks, error) {
+	for event := range commands {
+		if !valid(event) {
+			return nil, fmt.Errorf("unknown hook event %q, expected one of %v", event, Events)
+		}
+	}
+
+	return &Hooks{commands: commands, timeout: timeout}, nil
+}
+
+func valid(event string) bool {
+	for _, e := range Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Run runs the hooks for every event received until events is closed. Hooks
+// run one at a time in the order of their events, so e.g. a stopped hook
+// never runs before the started hook preceding it, each limited to the
+// timeout.
+func (h *Hooks) Run(events <-chan *pb.Event) {
+	for event := range queue(events) {
+		name := Name(event)
+		if name == "" {
+			continue
+		}
+
+		for _, command := range h.commands[name] {
+			h.exec(name, command, event)
+		}
+	}
+}
+
+// queue receives events as soon as they are sent, holding on to them until
+// they are received from the returned channel. This keeps the server from
+// dropping events while a slow hook runs.
+func queue(events <-chan *pb.Event) <-chan *pb.Event {
+	queued := make(chan *pb.Event)
+
+	go func() {
+		defer close(queued)
+
+		var pending []*pb.Event
+		for events != nil || len(pending) > 0 {
+			var out chan<- *pb.Event
+			var next *pb.Event
+			if len(pending) > 0 {
+				out, next = queued, pending[0]
+			}
+
+			select {
+			case event, ok := <-events:
+				if !ok {
+					events = nil
+					continue
+				}
+
+				pending = append(pending, event)
+			case out <- next:
+				pending = pending[1:]
+			}
+		}
+	}()
+
+	return queued
+}
+
+// Name returns the name of the hook event corresponding to event, or an empty
+// string if no hooks run for it.
+func Name(event *pb.Event) string {
+	switch event.GetType() {
+	case pb.EventType_EVENT_STARTED:
+		if event.GetPhase() == pb.Phase_PHASE_WORK {
+			return Started
+		}
+
+		return Break
+	case pb.EventType_EVENT_STOPPED:
+		return Stopped
+	case pb.EventType_EVENT_COMPLETED:
+		return Completed
+	case pb.EventType_EVENT_PAUSED:
+		return Paused
+	case pb.EventType_EVENT_RESUMED:
+		return Resumed
+	default:
+		return ""
+	}
+}
+
+func (h *Hooks) exec(name, command string, event *pb.Event) {
+	endsAt := ""
+	if event.GetEndsAt() != nil {
+		endsAt = event.GetEndsAt().AsTime().Format(time.RFC3339)
+	}
+
+	outcome := ""
+	if event.GetOutcome() != pb.Outcome_OUTCOME_UNKNOWN {
+		outcome = strings.ToLower(strings.TrimPrefix(event.GetOutcome().String(), "OUTCOME_"))
+	}
+
+	var output bytes.Buffer
+
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	// Run the command in its own process group so that any children it
+	// spawns are killed along with it on timeout.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Env = append(
+		os.Environ(),
+		"TOMATO_EVENT="+name,
+		"TOMATO_PHASE="+strings.ToLower(strings.TrimPrefix(event.GetPhase().String(), "PHASE_")),
+		"TOMATO_ENDS_AT="+endsAt,
+		"TOMATO_REMAINING="+strconv.FormatInt(int64(event.GetRemaining().AsDuration().Seconds()), 10),
+		"TOMATO_OUTCOME="+outcome,
+		"TOMATO_REASON="+event.GetReason(),
+	)
+
+	log.Printf("Running %v hook: %v", name, command)
+
+	if err := cmd.Start(); err != nil {
+		log.Printf("%v hook failed to start: %v: %v", name, command, err)
+		return
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	timeout := time.NewTimer(h.timeout)
+	defer timeout.Stop()
+
+	var err error
+	timedOut := false
+
+	select {
+	case err = <-done:
+	case <-timeout.C:
+		timedOut = true
+		syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		err = <-done
+	}
+
+	scanner := bufio.NewScanner(&output)
+	for scanner.Scan() {
+		log.Printf("[%v hook] %s", name, scanner.Bytes())
+	}
+
+	if timedOut {
+		log.Printf("%v hook timed out after %v: %v", name, h.timeout, command)
+		return
+	}
+
+	if err != nil {
+		log.Printf("%v hook failed: %v: %v", name, command, err)
+	}
+}
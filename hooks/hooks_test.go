@@ -0,0 +1,200 @@
+package hooks_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/CGA1123/tomato/hooks"
+	"github.com/CGA1123/tomato/pb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// run runs hooks for each of events, returning once they have all exited.
+func run(t *testing.T, h *hooks.Hooks, events ...*pb.Event) {
+	t.Helper()
+
+	ch := make(chan *pb.Event, len(events))
+	for _, event := range events {
+		ch <- event
+	}
+	close(ch)
+
+	h.Run(ch)
+}
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		event *pb.Event
+		name  string
+	}{
+		{&pb.Event{Type: pb.EventType_EVENT_STARTED, Phase: pb.Phase_PHASE_WORK}, hooks.Started},
+		{&pb.Event{Type: pb.EventType_EVENT_STARTED, Phase: pb.Phase_PHASE_SHORT_BREAK}, hooks.Break},
+		{&pb.Event{Type: pb.EventType_EVENT_STARTED, Phase: pb.Phase_PHASE_LONG_BREAK}, hooks.Break},
+		{&pb.Event{Type: pb.EventType_EVENT_STOPPED}, hooks.Stopped},
+		{&pb.Event{Type: pb.EventType_EVENT_COMPLETED}, hooks.Completed},
+		{&pb.Event{Type: pb.EventType_EVENT_PAUSED}, hooks.Paused},
+		{&pb.Event{Type: pb.EventType_EVENT_RESUMED}, hooks.Resumed},
+		{&pb.Event{Type: pb.EventType_EVENT_TICK}, ""},
+		{&pb.Event{Type: pb.EventType_EVENT_PHASE_CHANGED}, ""},
+	}
+
+	for _, test := range tests {
+		if got := hooks.Name(test.event); got != test.name {
+			t.Errorf("expected %v %v to run %q hooks, got %q", test.event.GetType(), test.event.GetPhase(), test.name, got)
+		}
+	}
+}
+
+func TestNewUnknownEvent(t *testing.T) {
+	if _, err := hooks.New(map[string][]string{"finished": {"true"}}, time.Second); err == nil {
+		t.Fatalf("expected an unknown event to be refused")
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	env := filepath.Join(dir, "env")
+	ran := filepath.Join(dir, "ran")
+
+	h, err := hooks.New(map[string][]string{
+		hooks.Stopped: {"env > " + env},
+		hooks.Started: {"echo started >> " + ran},
+	}, 5*time.Second)
+	if err != nil {
+		t.Fatalf("error creating hooks: %v", err)
+	}
+
+	endsAt := time.Date(2021, 6, 1, 9, 25, 0, 0, time.UTC)
+	run(t, h, &pb.Event{
+		Type:      pb.EventType_EVENT_STOPPED,
+		Phase:     pb.Phase_PHASE_WORK,
+		EndsAt:    timestamppb.New(endsAt),
+		Remaining: durationpb.New(90 * time.Second),
+		Outcome:   pb.Outcome_OUTCOME_STOPPED,
+		Reason:    "fire alarm",
+	}, &pb.Event{Type: pb.EventType_EVENT_TICK})
+
+	contents, err := ioutil.ReadFile(env)
+	if err != nil {
+		t.Fatalf("error reading hook environment: %v", err)
+	}
+
+	want := []string{
+		"TOMATO_EVENT=stopped",
+		"TOMATO_PHASE=work",
+		"TOMATO_ENDS_AT=2021-06-01T09:25:00Z",
+		"TOMATO_REMAINING=90",
+		"TOMATO_OUTCOME=stopped",
+		"TOMATO_REASON=fire alarm",
+	}
+
+	lines := strings.Split(string(contents), "\n")
+	for _, variable := range want {
+		found := false
+		for _, line := range lines {
+			if line == variable {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Errorf("expected %v in the hook's environment", variable)
+		}
+	}
+
+	if _, err := os.Stat(ran); !os.IsNotExist(err) {
+		t.Errorf("expected only stopped hooks to run, started hook ran")
+	}
+}
+
+func TestOrder(t *testing.T) {
+	ran := filepath.Join(t.TempDir(), "ran")
+
+	// A slow started hook still finishes before the stopped hook runs.
+	h, err := hooks.New(map[string][]string{
+		hooks.Started: {"sleep 0.2; echo started >> " + ran},
+		hooks.Stopped: {"echo stopped >> " + ran, "echo stopped again >> " + ran},
+	}, 5*time.Second)
+	if err != nil {
+		t.Fatalf("error creating hooks: %v", err)
+	}
+
+	run(t, h,
+		&pb.Event{Type: pb.EventType_EVENT_STARTED, Phase: pb.Phase_PHASE_WORK},
+		&pb.Event{Type: pb.EventType_EVENT_STOPPED, Phase: pb.Phase_PHASE_WORK},
+	)
+
+	contents, err := ioutil.ReadFile(ran)
+	if err != nil {
+		t.Fatalf("error reading hook output: %v", err)
+	}
+
+	if want := "started\nstopped\nstopped again\n"; string(contents) != want {
+		t.Fatalf("expected hooks to run in order %q, got %q", want, contents)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	pidFile := filepath.Join(t.TempDir(), "pid")
+
+	// The hook's child is killed along with it, or Run would wait on it.
+	h, err := hooks.New(map[string][]string{
+		hooks.Paused: {"sleep 10 & echo $! > " + pidFile + "; wait"},
+	}, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("error creating hooks: %v", err)
+	}
+
+	began := time.Now()
+	run(t, h, &pb.Event{Type: pb.EventType_EVENT_PAUSED})
+
+	if took := time.Since(began); took > 5*time.Second {
+		t.Fatalf("expected the hook to be killed after 100ms, took %v", took)
+	}
+
+	contents, err := ioutil.ReadFile(pidFile)
+	if err != nil {
+		t.Fatalf("error reading pid: %v", err)
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(contents)))
+	if err != nil {
+		t.Fatalf("error parsing pid: %v", err)
+	}
+
+	// SIGKILL is delivered asynchronously, give it a moment to land.
+	for deadline := time.Now().Add(2 * time.Second); alive(pid); {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the hook's child to have been killed")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// alive returns whether pid is running, a zombie left for its new parent to
+// reap counting as dead.
+func alive(pid int) bool {
+	if err := syscall.Kill(pid, 0); err == syscall.ESRCH {
+		return false
+	}
+
+	stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return !os.IsNotExist(err)
+	}
+
+	// The state follows the command, which is in parentheses.
+	fields := strings.Fields(string(stat[bytes.LastIndexByte(stat, ')')+1:]))
+
+	return len(fields) == 0 || fields[0] != "Z"
+}
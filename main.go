@@ -10,77 +10,326 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"syscall"
+	"text/tabwriter"
 	"time"
 
 	"github.com/CGA1123/tomato/client"
+	"github.com/CGA1123/tomato/config"
+	"github.com/CGA1123/tomato/gateway"
+	"github.com/CGA1123/tomato/hooks"
+	"github.com/CGA1123/tomato/notify"
 	"github.com/CGA1123/tomato/pb"
 	"github.com/CGA1123/tomato/server"
 	"github.com/soellman/pidfile"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
-	Socket        = "/tmp/tomato.sock"
-	LogFile       = "/tmp/tomato.log"
-	PidFile       = "/tmp/tomato.pid"
+	ConfigFile    = config.Path()
+	Socket        = filepath.Join(config.RuntimeDir(), "tomato.sock")
+	LogFile       = filepath.Join(config.StateDir(), "tomato.log")
+	PidFile       = filepath.Join(config.RuntimeDir(), "tomato.pid")
+	HistoryFile   = filepath.Join(config.StateDir(), "history")
+	StateFile     = filepath.Join(config.StateDir(), "state.json")
+	TasksFile     = filepath.Join(config.StateDir(), "tasks.json")
+	Notify        = true
+	NotifyTitle   = "🍅 tomato"
+	NotifyBody    = "{phase} complete, time for a {next}!"
+	DBusAddress   = ""
+	Hooks         = []string{}
+	HookTimeout   = hooks.Timeout
 	LogPrefix     = "🍅 "
 	Quiet         = false
+	Timeout       = 10 * time.Second
+	Retry         = false
 	ErrNotRunning = errors.New("not running")
+	ErrPaused     = errors.New("paused")
 )
 
 func main() {
 	cmd := Cmd()
 	err := cmd.ExecuteContext(context.Background())
 	if err != nil {
-		fmt.Printf("Error: %v", err)
+		// Machine readable output has already been printed, the exit code is
+		// all that is needed to signal these.
+		if !(machineReadable() && (err == ErrNotRunning || err == ErrPaused)) {
+			fmt.Printf("Error: %v", err)
+		}
+
 		if err == ErrNotRunning {
 			os.Exit(33)
 		}
 
+		if err == ErrPaused {
+			os.Exit(34)
+		}
+
 		os.Exit(1)
 	}
 }
 
 func Cmd() *cobra.Command {
 	rootCmd := &cobra.Command{
-		Use:           "tomato",
-		Short:         "tomato is a tomato timer for your terminal!",
-		SilenceUsage:  true,
-		SilenceErrors: true,
+		Use:               "tomato",
+		Short:             "tomato is a tomato timer for your terminal!",
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		PersistentPreRunE: configure,
 	}
 
+	rootCmd.PersistentFlags().StringVar(&ConfigFile, "config", ConfigFile, "config file to load settings from")
+	rootCmd.PersistentFlags().StringVar(&Socket, "socket", Socket, "unix socket the server listens on")
+	rootCmd.PersistentFlags().StringVar(&PidFile, "pid-file", PidFile, "file the server writes its pid to")
+	rootCmd.PersistentFlags().StringVar(&Addr, "addr", Addr, "tcp://host:port of a remote server to connect to instead of the socket")
+	rootCmd.PersistentFlags().StringVar(&Token, "token", Token, "token required by (or sent to) a server listening on tcp")
+	rootCmd.PersistentFlags().StringVar(&CAFile, "ca-file", CAFile, "certificate to trust when connecting to --addr, e.g. the server's self-signed cert.pem")
+	rootCmd.PersistentFlags().DurationVar(&Timeout, "timeout", Timeout, "give up on the server if a call takes longer than this, 0 to wait forever")
+	rootCmd.PersistentFlags().BoolVar(&Retry, "retry", Retry, "retry calls for a little while if the server is unavailable, e.g. restarting")
+	rootCmd.PersistentFlags().BoolVar(&Autostart, "autostart", Autostart, "start the tomato server in the background if it is not already running")
+	rootCmd.PersistentFlags().StringVarP(&Output, "output", "o", Output, "output mode, one of human, quiet, json or template")
+	rootCmd.PersistentFlags().StringVar(&Format, "format", Format, "Go template to render output with, implies --output template")
+	rootCmd.PersistentFlags().BoolVarP(&Quiet, "quiet", "q", Quiet, "only print the bare result, same as --output quiet")
+
 	rootCmd.AddCommand(
 		serve(),
 		kill(),
 		up(),
 		start(),
 		stop(),
+		pause(),
+		resume(),
+		history(),
+		stats(),
+		watch(),
 		running(),
+		status(),
+		interrupt(),
+		task(),
 		remaining(),
 	)
 
 	return rootCmd
 }
 
+// configure loads settings from the config file and environment, leaving any
+// set through flags untouched.
+// configGiven is set once configure finds the config file was asked for,
+// rather than being the default which need not exist.
+var configGiven bool
+
+func configure(cmd *cobra.Command, args []string) error {
+	cfg := config.Config{
+		Socket:      Socket,
+		PidFile:     PidFile,
+		LogFile:     LogFile,
+		LogPrefix:   LogPrefix,
+		HistoryFile: HistoryFile,
+		StateFile:   StateFile,
+		TasksFile:   TasksFile,
+		Quiet:       Quiet,
+		Autostart:   Autostart,
+		Timeout:     config.Duration{Duration: Timeout},
+		Retry:       Retry,
+		HTTP:        HTTP,
+		Listen:      Listen,
+		TLSCert:     TLSCert,
+		TLSKey:      TLSKey,
+		Token:       Token,
+		Addr:        Addr,
+		CAFile:      CAFile,
+		Timer: config.Timer{
+			Duration:       config.Duration{Duration: server.Duration},
+			ShortBreak:     config.Duration{Duration: server.ShortBreak},
+			LongBreak:      config.Duration{Duration: server.LongBreak},
+			LongBreakEvery: server.LongBreakEvery,
+		},
+		Notify: config.Notify{
+			Enabled:     Notify,
+			Title:       NotifyTitle,
+			Body:        NotifyBody,
+			DBusAddress: DBusAddress,
+		},
+		Hooks: config.Hooks{
+			Timeout: config.Duration{Duration: HookTimeout},
+		},
+	}
+
+	flags := cmd.Flags()
+
+	// Only the default config file may be missing, one asked for must exist.
+	configGiven = flags.Changed("config") || os.Getenv("TOMATO_CONFIG") != ""
+	if configGiven {
+		if _, err := os.Stat(ConfigFile); err != nil {
+			return fmt.Errorf("error reading config file: %w", err)
+		}
+	}
+
+	if err := config.Load(ConfigFile, &cfg); err != nil {
+		return err
+	}
+
+	apply := func(flag string, set func()) {
+		if !flags.Changed(flag) {
+			set()
+		}
+	}
+
+	apply("socket", func() { Socket = cfg.Socket })
+	apply("pid-file", func() { PidFile = cfg.PidFile })
+	apply("log-file", func() { LogFile = cfg.LogFile })
+	apply("log-prefix", func() { LogPrefix = cfg.LogPrefix })
+	apply("history-file", func() { HistoryFile = cfg.HistoryFile })
+	apply("state-file", func() { StateFile = cfg.StateFile })
+	apply("tasks-file", func() { TasksFile = cfg.TasksFile })
+	apply("quiet", func() { Quiet = cfg.Quiet })
+	apply("autostart", func() { Autostart = cfg.Autostart })
+	apply("timeout", func() { Timeout = cfg.Timeout.Duration })
+	apply("retry", func() { Retry = cfg.Retry })
+	apply("http", func() { HTTP = cfg.HTTP })
+	apply("listen", func() { Listen = cfg.Listen })
+	apply("tls-cert", func() { TLSCert = cfg.TLSCert })
+	apply("tls-key", func() { TLSKey = cfg.TLSKey })
+	apply("token", func() { Token = cfg.Token })
+	apply("addr", func() { Addr = cfg.Addr })
+	apply("ca-file", func() { CAFile = cfg.CAFile })
+	if Quiet && !flags.Changed("output") && !flags.Changed("format") {
+		Output = OutputQuiet
+	}
+	apply("notify", func() { Notify = cfg.Notify.Enabled })
+	apply("notify-title", func() { NotifyTitle = cfg.Notify.Title })
+	apply("notify-body", func() { NotifyBody = cfg.Notify.Body })
+	apply("dbus-address", func() { DBusAddress = cfg.Notify.DBusAddress })
+	apply("hook-timeout", func() { HookTimeout = cfg.Hooks.Timeout.Duration })
+	apply("hook", func() {
+		for event, commands := range cfg.Hooks.Commands() {
+			for _, command := range commands {
+				Hooks = append(Hooks, event+"="+command)
+			}
+		}
+	})
+
+	if cmd.Name() == "server" {
+		apply("duration", func() { server.Duration = cfg.Timer.Duration.Duration })
+		apply("short-break", func() { server.ShortBreak = cfg.Timer.ShortBreak.Duration })
+		apply("long-break", func() { server.LongBreak = cfg.Timer.LongBreak.Duration })
+		apply("long-break-every", func() { server.LongBreakEvery = cfg.Timer.LongBreakEvery })
+
+		if err := validateServer(); err != nil {
+			return err
+		}
+	}
+
+	if Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative, got %v", Timeout)
+	}
+
+	return configureOutput()
+}
+
+// validateServer checks the server's settings, however they were set.
+func validateServer() error {
+	if server.Duration < server.MinDuration || server.Duration > server.MaxDuration {
+		return fmt.Errorf("duration must be between %v and %v, got %v", server.MinDuration, server.MaxDuration, server.Duration)
+	}
+
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"short break", server.ShortBreak},
+		{"long break", server.LongBreak},
+		{"hook timeout", HookTimeout},
+	} {
+		if d.value <= 0 {
+			return fmt.Errorf("%v must be positive, got %v", d.name, d.value)
+		}
+	}
+
+	if server.LongBreakEvery < 1 {
+		return fmt.Errorf("long break every must be at least 1, got %d", server.LongBreakEvery)
+	}
+
+	return nil
+}
+
+// ensureDirs creates the directories holding the server's files, refusing to
+// use the default runtime directory if it is not private to this user.
+func ensureDirs() error {
+	for _, path := range []string{Socket, PidFile, LogFile, HistoryFile, StateFile, TasksFile} {
+		if err := config.EnsureDir(filepath.Dir(path)); err != nil {
+			return err
+		}
+	}
+
+	if filepath.Dir(Socket) == config.RuntimeDir() {
+		return config.CheckPrivate(config.RuntimeDir())
+	}
+
+	return nil
+}
+
+// NewClient connects client commands to the server, tests replace it to
+// connect to one running in-process instead.
+var NewClient = connect
+
 func WithClient(f func(*client.Client) error) error {
 	log.SetFlags(0)
 	log.SetPrefix(LogPrefix)
 
-	if !serverRunning() {
-		return errors.New("tomato server is not running")
+	c, err := NewClient()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	return f(c)
+}
+
+// connect dials the server at --addr, or the one listening on the local
+// socket, starting it first with --autostart.
+func connect() (*client.Client, error) {
+	target, opts, err := dialTarget()
+	if err != nil {
+		return nil, err
+	}
+
+	if Addr == "" && !serverRunning() {
+		if !Autostart {
+			return nil, errors.New("tomato server is not running")
+		}
+
+		if err := startServer(); err != nil {
+			return nil, err
+		}
 	}
 
-	c, err := client.New(Socket)
+	c, err := client.New(target, append(opts, callOptions()...)...)
 	if err != nil {
 		log.Printf("is the server running? start it with tomato server")
-		return fmt.Errorf("error creating client: %w", err)
+		return nil, fmt.Errorf("error creating client: %w", err)
 	}
 
-	return f(c)
+	return c, nil
+}
+
+// callOptions applies --timeout and --retry to each call made by a client.
+func callOptions() []client.Option {
+	var opts []client.Option
+	if Timeout > 0 {
+		opts = append(opts, client.WithTimeout(Timeout))
+	}
+
+	if Retry {
+		opts = append(opts, client.WithRetry(client.DefaultRetryPolicy))
+	}
+
+	return opts
 }
 
 func serverRunning() bool {
@@ -132,29 +381,35 @@ func remaining() *cobra.Command {
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return WithClient(func(c *client.Client) error {
-				left, err := c.Remaining()
+				status, err := c.Status()
 				if err != nil {
 					return err
 				}
 
-				if left == time.Duration(0) {
-					if Quiet {
-						fmt.Println("0")
-					} else {
-						log.Printf("the clock was not running!")
-						log.Printf("use `tomato start` to get going.")
-					}
-					return nil
+				left := status.GetRemaining().AsDuration()
+				phase := statusPhase(status)
+
+				result := struct {
+					Remaining duration `json:"remaining"`
+					Phase     string   `json:"phase"`
+				}{
+					Remaining: duration(left),
+					Phase:     enumName(phase.GetPhase(), "PHASE_"),
 				}
 
 				minutes := left.Round(time.Minute).Minutes()
 
-				if Quiet {
+				return report(result, func() {
+					if left == time.Duration(0) {
+						log.Printf("the clock was not running!")
+						log.Printf("use `tomato start` to get going.")
+						return
+					}
+
+					log.Printf("there are %.0f minutes left on the clock, you are %v!", minutes, describePhase(phase))
+				}, func() {
 					fmt.Printf("%.0f\n", minutes)
-				} else {
-					log.Printf("there are %.0f minutes left on the clock!", minutes)
-				}
-				return nil
+				})
 			})
 		},
 	}
@@ -162,89 +417,792 @@ func remaining() *cobra.Command {
 
 func running() *cobra.Command {
 	return &cobra.Command{
-		Use:   "runnning",
-		Short: "Checks whether there is a current tomato running.",
+		Use:   "running",
+		Short: "Checks whether there is a current tomato or break running.",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return WithClient(func(c *client.Client) error {
-				running, err := c.Running()
+				status, err := c.Status()
+				if err != nil {
+					return err
+				}
+
+				state := status.GetState()
+				phase := statusPhase(status)
+
+				result := struct {
+					State          string `json:"state"`
+					Phase          string `json:"phase"`
+					Completed      uint32 `json:"completed"`
+					LongBreakEvery uint32 `json:"long_break_every"`
+				}{
+					State:          enumName(state, "STATE_"),
+					Phase:          enumName(phase.GetPhase(), "PHASE_"),
+					Completed:      phase.GetCompleted(),
+					LongBreakEvery: phase.GetLongBreakEvery(),
+				}
+
+				err = report(result, func() {
+					switch state {
+					case pb.State_STATE_RUNNING:
+						log.Printf("you are %v!", describePhase(phase))
+					case pb.State_STATE_PAUSED:
+						log.Printf("you are %v, but the clock is paused!", describePhase(phase))
+					}
+				}, func() {})
 				if err != nil {
 					return err
 				}
 
-				if !running {
+				switch state {
+				case pb.State_STATE_STOPPED:
 					return ErrNotRunning
+				case pb.State_STATE_PAUSED:
+					return ErrPaused
+				default:
+					return nil
 				}
+			})
+		},
+	}
+}
 
-				return nil
+type statusResult struct {
+	State          string     `json:"state"`
+	Phase          string     `json:"phase"`
+	StartedAt      *time.Time `json:"started_at,omitempty"`
+	EndsAt         *time.Time `json:"ends_at,omitempty"`
+	Remaining      duration   `json:"remaining"`
+	PausedAt       *time.Time `json:"paused_at,omitempty"`
+	Label          string     `json:"label,omitempty"`
+	Tags           []string   `json:"tags,omitempty"`
+	Task           uint32     `json:"task,omitempty"`
+	Interruptions  uint32     `json:"interruptions"`
+	Cycle          uint32     `json:"cycle"`
+	LongBreakEvery uint32     `json:"long_break_every"`
+}
+
+// optionalTime returns ts as a time, or nil when unset.
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+
+	t := ts.AsTime()
+
+	return &t
+}
+
+func status() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Shows everything about the current tomato or break at once.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return WithClient(func(c *client.Client) error {
+				status, err := c.Status()
+				if err != nil {
+					return err
+				}
+
+				result := statusResult{
+					State:          enumName(status.GetState(), "STATE_"),
+					Phase:          enumName(status.GetPhase(), "PHASE_"),
+					StartedAt:      optionalTime(status.GetStartedAt()),
+					EndsAt:         optionalTime(status.GetEndsAt()),
+					Remaining:      duration(status.GetRemaining().AsDuration()),
+					PausedAt:       optionalTime(status.GetPausedAt()),
+					Label:          status.GetLabel(),
+					Tags:           status.GetTags(),
+					Task:           status.GetTask(),
+					Interruptions:  status.GetInterruptions(),
+					Cycle:          status.GetCycle(),
+					LongBreakEvery: status.GetLongBreakEvery(),
+				}
+
+				phase := describePhase(statusPhase(status))
+
+				if label := describeLabel(status.GetLabel(), status.GetTags()); label != "" {
+					phase += " (" + label + ")"
+				}
+
+				return report(result, func() {
+					switch status.GetState() {
+					case pb.State_STATE_RUNNING:
+						log.Printf("you are %v, %v left on the clock, finishing at %v.", phase, result.Remaining, status.GetEndsAt().AsTime().Local().Format("15:04"))
+					case pb.State_STATE_PAUSED:
+						log.Printf("you are %v, paused at %v with %v left on the clock.", phase, status.GetPausedAt().AsTime().Local().Format("15:04"), result.Remaining)
+					default:
+						log.Printf("the clock is not running, you have completed %d of %d tomatoes this cycle.", status.GetCycle(), status.GetLongBreakEvery())
+					}
+				}, func() {
+					fmt.Println(result.State)
+				})
 			})
 		},
 	}
 }
 
+// describeLabel formats a label and tags for display, e.g. review PR #42
+// [review, backend].
+func describeLabel(label string, tags []string) string {
+	if len(tags) == 0 {
+		return label
+	}
+
+	return strings.TrimSpace(label + " [" + strings.Join(tags, ", ") + "]")
+}
+
+func interrupt() *cobra.Command {
+	var external bool
+
+	cmd := &cobra.Command{
+		Use:   "interrupt [note]",
+		Short: "Records an interruption against the current tomato, without stopping it.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			note := ""
+			if len(args) > 0 {
+				note = args[0]
+			}
+
+			return WithClient(func(c *client.Client) error {
+				interruption, err := c.Interrupt(external, note)
+				if err != nil {
+					return err
+				}
+
+				status, err := c.Status()
+				if err != nil {
+					return err
+				}
+
+				return report(newInterruptionResult(interruption), func() {
+					log.Printf("noted, that is %d interruption(s) this tomato.", status.GetInterruptions())
+					log.Printf("get back to it!")
+				}, func() {
+					fmt.Println(status.GetInterruptions())
+				})
+			})
+		},
+	}
+
+	cmd.Flags().BoolVarP(&external, "external", "e", false, "the interruption came from somebody or something else, rather than yourself")
+
+	return cmd
+}
+
+// statusPhase returns the phase part of status, as describePhase takes it.
+func statusPhase(status *pb.TomatoStatus) *pb.PhaseResponse {
+	return &pb.PhaseResponse{
+		Phase:          status.GetPhase(),
+		Completed:      status.GetCycle(),
+		LongBreakEvery: status.GetLongBreakEvery(),
+	}
+}
+
+func describePhase(phase *pb.PhaseResponse) string {
+	switch phase.GetPhase() {
+	case pb.Phase_PHASE_WORK:
+		return fmt.Sprintf("working on tomato %d of %d", phase.GetCompleted()+1, phase.GetLongBreakEvery())
+	case pb.Phase_PHASE_SHORT_BREAK:
+		return "on a short break"
+	case pb.Phase_PHASE_LONG_BREAK:
+		return "on a long break"
+	default:
+		return "idle"
+	}
+}
+
 func stop() *cobra.Command {
 	return &cobra.Command{
-		Use:   "stop",
-		Short: "Stop the currently running tomato.",
-		Args:  cobra.NoArgs,
+		Use:   "stop [reason]",
+		Short: "Stop the currently running tomato, or skip the current break.",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			reason := ""
+			if len(args) > 0 {
+				reason = args[0]
+			}
+
 			return WithClient(func(c *client.Client) error {
-				left, err := c.Stop()
+				left, err := c.StopWithReason(reason)
 				if err != nil {
 					return err
 				}
 
+				result := struct {
+					Remaining duration `json:"remaining"`
+				}{Remaining: duration(left)}
+
 				minutes := left.Round(time.Minute).Minutes()
 
-				if Quiet {
-					fmt.Printf("%.0f\n", minutes)
-				} else {
+				return report(result, func() {
 					log.Printf("there was %.0f minute(s) left on the clock!", minutes)
+				}, func() {
+					fmt.Printf("%.0f\n", minutes)
+				})
+			})
+		},
+	}
+}
+
+func pause() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pause",
+		Short: "Pause the currently running tomato.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return WithClient(func(c *client.Client) error {
+				left, err := c.Pause()
+				if err != nil {
+					return err
 				}
 
-				return nil
+				result := struct {
+					Remaining duration `json:"remaining"`
+				}{Remaining: duration(left)}
+
+				minutes := left.Round(time.Minute).Minutes()
+
+				return report(result, func() {
+					log.Printf("paused with %.0f minute(s) left on the clock.", minutes)
+					log.Printf("use `tomato resume` to carry on.")
+				}, func() {
+					fmt.Printf("%.0f\n", minutes)
+				})
 			})
 		},
 	}
 }
 
-func start() *cobra.Command {
+func resume() *cobra.Command {
 	return &cobra.Command{
-		Use:   "start",
-		Short: "Starts a tomato timer",
+		Use:   "resume",
+		Short: "Resume the currently paused tomato.",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return WithClient(func(c *client.Client) error {
-				finish, err := c.Start()
+				finish, err := c.Resume()
 				if err != nil {
 					return err
 				}
 
-				fmtd := finish.Format("15:04")
-				if Quiet {
-					fmt.Println(fmtd)
-				} else {
-					log.Printf("timer will finish at %v", fmtd)
+				return reportFinish(finish)
+			})
+		},
+	}
+}
+
+// reportFinish prints the time the current timer will finish at.
+func reportFinish(finish time.Time) error {
+	result := struct {
+		EndsAt time.Time `json:"ends_at"`
+	}{EndsAt: finish}
+
+	fmtd := finish.Format("15:04")
+
+	return report(result, func() {
+		log.Printf("timer will finish at %v", fmtd)
+	}, func() {
+		fmt.Println(fmtd)
+	})
+}
+
+// parseTime parses a point in time given on the command line, either as a
+// date, an RFC3339 timestamp, or a duration into the past (e.g. 24h).
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q, expected a date (2006-01-02), timestamp (RFC3339) or duration (24h)", value)
+}
+
+func describeOutcome(outcome pb.Outcome) string {
+	switch outcome {
+	case pb.Outcome_OUTCOME_COMPLETED:
+		return "completed"
+	case pb.Outcome_OUTCOME_STOPPED:
+		return "stopped"
+	case pb.Outcome_OUTCOME_ABANDONED:
+		return "abandoned"
+	case pb.Outcome_OUTCOME_SKIPPED:
+		return "skipped"
+	default:
+		return "unknown"
+	}
+}
+
+// describeReason appends the reason given for stopping to description, if
+// there was one.
+func describeReason(description, reason string) string {
+	if reason == "" {
+		return description
+	}
+
+	return fmt.Sprintf("%v (%v)", description, reason)
+}
+
+func describeSessionPhase(phase pb.Phase) string {
+	switch phase {
+	case pb.Phase_PHASE_WORK:
+		return "tomato"
+	case pb.Phase_PHASE_SHORT_BREAK:
+		return "short break"
+	case pb.Phase_PHASE_LONG_BREAK:
+		return "long break"
+	default:
+		return "unknown"
+	}
+}
+
+type sessionResult struct {
+	Phase     string    `json:"phase"`
+	Outcome   string    `json:"outcome"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+	Planned   duration  `json:"planned"`
+	Actual    duration  `json:"actual"`
+	Label     string    `json:"label,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	Task      uint32    `json:"task,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+
+	Interruptions []interruptionResult `json:"interruptions"`
+}
+
+type interruptionResult struct {
+	Kind string    `json:"kind"`
+	At   time.Time `json:"at"`
+	Note string    `json:"note,omitempty"`
+}
+
+func newInterruptionResult(interruption *pb.Interruption) interruptionResult {
+	return interruptionResult{
+		Kind: enumName(interruption.GetKind(), "INTERRUPTION_"),
+		At:   interruption.GetAt().AsTime(),
+		Note: interruption.GetNote(),
+	}
+}
+
+func history() *cobra.Command {
+	var since, until string
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Lists previous tomatoes and breaks.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, err := parseTime(since)
+			if err != nil {
+				return err
+			}
+
+			to, err := parseTime(until)
+			if err != nil {
+				return err
+			}
+
+			return WithClient(func(c *client.Client) error {
+				sessions, err := c.History(from, to)
+				if err != nil {
+					return err
 				}
 
-				return nil
+				results := make([]sessionResult, 0, len(sessions))
+				for _, session := range sessions {
+					interruptions := make([]interruptionResult, 0, len(session.GetInterruptions()))
+					for _, interruption := range session.GetInterruptions() {
+						interruptions = append(interruptions, newInterruptionResult(interruption))
+					}
+
+					results = append(results, sessionResult{
+						Phase:     enumName(session.GetPhase(), "PHASE_"),
+						Outcome:   enumName(session.GetOutcome(), "OUTCOME_"),
+						StartedAt: session.GetStartedAt().AsTime(),
+						EndedAt:   session.GetEndedAt().AsTime(),
+						Planned:   duration(session.GetPlanned().AsDuration()),
+						Actual:    duration(session.GetActual().AsDuration()),
+						Label:     session.GetLabel(),
+						Tags:      session.GetTags(),
+						Task:      session.GetTask(),
+						Reason:    session.GetReason(),
+
+						Interruptions: interruptions,
+					})
+				}
+
+				table := func(header bool) func() {
+					return func() {
+						w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+						if header {
+							fmt.Fprintln(w, "STARTED\tPHASE\tPLANNED\tACTUAL\tOUTCOME\tINTERRUPTIONS\tLABEL")
+						}
+
+						for _, session := range sessions {
+							fmt.Fprintf(
+								w,
+								"%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+								session.GetStartedAt().AsTime().Local().Format("2006-01-02 15:04"),
+								describeSessionPhase(session.GetPhase()),
+								session.GetPlanned().AsDuration().Round(time.Second),
+								session.GetActual().AsDuration().Round(time.Second),
+								describeReason(describeOutcome(session.GetOutcome()), session.GetReason()),
+								len(session.GetInterruptions()),
+								describeLabel(session.GetLabel(), session.GetTags()),
+							)
+						}
+
+						w.Flush()
+					}
+				}
+
+				return report(results, table(true), table(false))
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "only show sessions started since this date, timestamp or duration ago")
+	cmd.Flags().StringVar(&until, "until", "", "only show sessions started before this date, timestamp or duration ago")
+
+	return cmd
+}
+
+type dayResult struct {
+	Date      string   `json:"date"`
+	Completed uint32   `json:"completed"`
+	Focused   duration `json:"focused"`
+}
+
+type tagResult struct {
+	Tag       string   `json:"tag"`
+	Completed uint32   `json:"completed"`
+	Focused   duration `json:"focused"`
+}
+
+type statsResult struct {
+	Completed      uint32      `json:"completed"`
+	Stopped        uint32      `json:"stopped"`
+	Abandoned      uint32      `json:"abandoned"`
+	Focused        duration    `json:"focused"`
+	CompletionRate float64     `json:"completion_rate"`
+	CurrentStreak  uint32      `json:"current_streak"`
+	LongestStreak  uint32      `json:"longest_streak"`
+	Days           []dayResult `json:"days"`
+	Tags           []tagResult `json:"tags"`
+
+	InternalInterruptions uint32 `json:"internal_interruptions"`
+	ExternalInterruptions uint32 `json:"external_interruptions"`
+}
+
+func stats() *cobra.Command {
+	var since, until string
+	var week, month bool
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Reports how productive you have been, over the last 7 days by default.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			now := time.Now()
+			today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+			windows := 0
+			for _, set := range []bool{since != "", week, month} {
+				if set {
+					windows++
+				}
+			}
+
+			if windows > 1 {
+				return fmt.Errorf("only one of --since, --week and --month may be given")
+			}
+
+			var from time.Time
+			switch {
+			case since != "":
+				t, err := parseTime(since)
+				if err != nil {
+					return err
+				}
+
+				from = t
+			case week:
+				// Weeks start on a Monday.
+				from = today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+			case month:
+				from = today.AddDate(0, 0, 1-today.Day())
+			default:
+				from = today.AddDate(0, 0, -6)
+			}
+
+			to, err := parseTime(until)
+			if err != nil {
+				return err
+			}
+
+			return WithClient(func(c *client.Client) error {
+				stats, err := c.Stats(from, to)
+				if err != nil {
+					return err
+				}
+
+				result := statsResult{
+					Completed:      stats.GetCompleted(),
+					Stopped:        stats.GetStopped(),
+					Abandoned:      stats.GetAbandoned(),
+					Focused:        duration(stats.GetFocused().AsDuration()),
+					CompletionRate: stats.GetCompletionRate(),
+					CurrentStreak:  stats.GetCurrentStreak(),
+					LongestStreak:  stats.GetLongestStreak(),
+					Days:           []dayResult{},
+					Tags:           []tagResult{},
+
+					InternalInterruptions: stats.GetInternalInterruptions(),
+					ExternalInterruptions: stats.GetExternalInterruptions(),
+				}
+
+				for _, day := range stats.GetDays() {
+					result.Days = append(result.Days, dayResult{
+						Date:      day.GetDate(),
+						Completed: day.GetCompleted(),
+						Focused:   duration(day.GetFocused().AsDuration()),
+					})
+				}
+
+				for _, tag := range stats.GetTags() {
+					result.Tags = append(result.Tags, tagResult{
+						Tag:       tag.GetTag(),
+						Completed: tag.GetCompleted(),
+						Focused:   duration(tag.GetFocused().AsDuration()),
+					})
+				}
+
+				table := func(header bool) func() {
+					return func() {
+						w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+						if header {
+							fmt.Fprintln(w, "DATE\tTOMATOES\tFOCUSED")
+						}
+
+						for _, day := range stats.GetDays() {
+							fmt.Fprintf(w, "%v\t%v\t%v\n", day.GetDate(), day.GetCompleted(), day.GetFocused().AsDuration().Round(time.Minute))
+						}
+
+						w.Flush()
+					}
+				}
+
+				return report(result, func() {
+					log.Printf(
+						"completed %d tomato(es), focused for %v, %.0f%% completion rate",
+						stats.GetCompleted(),
+						stats.GetFocused().AsDuration().Round(time.Minute),
+						stats.GetCompletionRate()*100,
+					)
+					log.Printf(
+						"current streak is %d day(s), longest streak is %d day(s)",
+						stats.GetCurrentStreak(),
+						stats.GetLongestStreak(),
+					)
+					log.Printf(
+						"interrupted %d time(s) by yourself and %d time(s) by others",
+						stats.GetInternalInterruptions(),
+						stats.GetExternalInterruptions(),
+					)
+
+					table(true)()
+
+					if len(stats.GetTags()) == 0 {
+						return
+					}
+
+					fmt.Println()
+					w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+					fmt.Fprintln(w, "TAG\tTOMATOES\tFOCUSED")
+					for _, tag := range stats.GetTags() {
+						fmt.Fprintf(w, "%v\t%v\t%v\n", tag.GetTag(), tag.GetCompleted(), tag.GetFocused().AsDuration().Round(time.Minute))
+					}
+					w.Flush()
+				}, table(false))
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "report on sessions started since this date, timestamp or duration ago")
+	cmd.Flags().StringVar(&until, "until", "", "report on sessions started before this date, timestamp or duration ago")
+	cmd.Flags().BoolVar(&week, "week", false, "report on this week, since Monday")
+	cmd.Flags().BoolVar(&month, "month", false, "report on this month, since the 1st")
 
+	return cmd
+}
+
+// enumName formats a protobuf enum value for display, e.g. PHASE_SHORT_BREAK
+// becomes short_break.
+func enumName(value fmt.Stringer, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(value.String(), prefix))
+}
+
+type watchEvent struct {
+	Type      string     `json:"type"`
+	At        time.Time  `json:"at"`
+	Phase     string     `json:"phase"`
+	State     string     `json:"state"`
+	Remaining duration   `json:"remaining"`
+	EndsAt    *time.Time `json:"ends_at,omitempty"`
+	Outcome   string     `json:"outcome,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+}
+
+func describeEvent(event *pb.Event) string {
+	phase := describeSessionPhase(event.GetPhase())
+	left := event.GetRemaining().AsDuration().Round(time.Second)
+
+	switch event.GetType() {
+	case pb.EventType_EVENT_STARTED:
+		return fmt.Sprintf("%v started, finishing at %v", phase, event.GetEndsAt().AsTime().Local().Format("15:04"))
+	case pb.EventType_EVENT_TICK:
+		return fmt.Sprintf("%v left on the %v", left, phase)
+	case pb.EventType_EVENT_PAUSED:
+		return fmt.Sprintf("%v paused with %v left", phase, left)
+	case pb.EventType_EVENT_RESUMED:
+		return fmt.Sprintf("%v resumed, finishing at %v", phase, event.GetEndsAt().AsTime().Local().Format("15:04"))
+	case pb.EventType_EVENT_STOPPED:
+		verb := "stopped"
+		if event.GetOutcome() == pb.Outcome_OUTCOME_SKIPPED {
+			verb = "skipped"
+		}
+
+		return describeReason(fmt.Sprintf("%v %v with %v left", phase, verb, left), event.GetReason())
+	case pb.EventType_EVENT_COMPLETED:
+		return fmt.Sprintf("%v completed", phase)
+	case pb.EventType_EVENT_PHASE_CHANGED:
+		if event.GetPhase() == pb.Phase_PHASE_IDLE {
+			return "now idle"
+		}
+
+		return fmt.Sprintf("now on a %v", phase)
+	default:
+		return "unknown event"
+	}
+}
+
+func watch() *cobra.Command {
+	var tick time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Prints timer events as they happen.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return WithClient(func(c *client.Client) error {
+				events, stop, err := c.Watch(tick)
+				if err != nil {
+					return err
+				}
+				defer stop()
+
+				for event := range events {
+					line := watchEvent{
+						Type:      enumName(event.GetType(), "EVENT_"),
+						At:        event.GetAt().AsTime(),
+						Phase:     enumName(event.GetPhase(), "PHASE_"),
+						State:     enumName(event.GetState(), "STATE_"),
+						Remaining: duration(event.GetRemaining().AsDuration()),
+						EndsAt:    optionalTime(event.GetEndsAt()),
+						Reason:    event.GetReason(),
+					}
+
+					if event.GetOutcome() != pb.Outcome_OUTCOME_UNKNOWN {
+						line.Outcome = enumName(event.GetOutcome(), "OUTCOME_")
+					}
+
+					err := report(line, func() {
+						log.Print(describeEvent(event))
+					}, func() {
+						fmt.Printf("%.0f\n", event.GetRemaining().AsDuration().Round(time.Minute).Minutes())
+					})
+					if err != nil {
+						return err
+					}
+				}
+
+				return errors.New("lost connection to the tomato server")
+			})
+		},
+	}
+
+	cmd.Flags().DurationVar(&tick, "tick", time.Second, "how often to print the time remaining")
+
+	return cmd
+}
+
+func start() *cobra.Command {
+	var duration time.Duration
+	var tags []string
+	var task uint32
+
+	cmd := &cobra.Command{
+		Use:   "start [label]",
+		Short: "Starts a tomato timer, optionally labelled with what it will be spent on",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			label := ""
+			if len(args) > 0 {
+				label = args[0]
+			}
+
+			return WithClient(func(c *client.Client) error {
+				finish, err := c.StartWith(client.StartOptions{
+					Duration: duration,
+					Label:    label,
+					Tags:     tags,
+					Task:     task,
+				})
+				if err != nil {
+					return err
+				}
+
+				return reportFinish(finish)
 			})
 		},
 	}
+
+	cmd.Flags().DurationVarP(&duration, "duration", "d", 0, "how long the tomato should last (default 25m)")
+	cmd.Flags().StringArrayVarP(&tags, "tag", "t", nil, "tag the tomato to group it by in stats (repeatable)")
+	cmd.Flags().Uint32Var(&task, "task", 0, "id of a task to count the tomato against, see tomato task")
+
+	return cmd
 }
 
 func serve() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "server",
 		Short: "Starts the tomato server.",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := ensureDirs(); err != nil {
+				return err
+			}
+
 			if err := pidfile.WriteControl(PidFile, os.Getpid(), true); err != nil {
 				return err
 			}
 
-			f, err := os.Create(LogFile)
+			f, err := os.OpenFile(LogFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
 			if err != nil {
 				log.Printf("error creating logfile: %v", err)
 			}
@@ -269,16 +1227,73 @@ func serve() *cobra.Command {
 				return fmt.Errorf("error opening socket: %w", err)
 			}
 
+			log.Printf("Recording history to: %v", HistoryFile)
+			log.Printf("Persisting timer to: %v", StateFile)
+			opts := []server.Option{
+				server.WithHistory(server.NewHistory(HistoryFile)),
+				server.WithStateFile(server.NewStateFile(StateFile)),
+				server.WithTasks(server.NewTasks(TasksFile)),
+			}
+
+			if Notify {
+				notifier, err := notify.NewDBus(DBusAddress)
+				if err != nil {
+					log.Printf("Desktop notifications disabled: %v", err)
+				} else {
+					defer notifier.Close()
+					opts = append(opts, server.WithNotifier(notifier, NotifyTitle, NotifyBody))
+				}
+			}
+
+			if len(Hooks) > 0 {
+				h, err := newHooks(Hooks, HookTimeout)
+				if err != nil {
+					return err
+				}
+
+				opts = append(opts, server.WithHooks(h))
+			}
+
+			tomato := server.New(opts...)
+			defer tomato.Close()
+
 			srv := grpc.NewServer()
-			pb.RegisterTomatoServiceServer(srv, server.New())
+			pb.RegisterTomatoServiceServer(srv, tomato)
 
-			errorC := make(chan error, 1)
+			errorC := make(chan error, 3)
 			shutdownC := make(chan os.Signal, 1)
 
+			var api http.Handler
+			if HTTP != "" {
+				api = gateway.New(tomato)
+			}
+
 			go func(errC chan<- error) {
-				errorC <- srv.Serve(listener)
+				if HTTP == "socket" {
+					log.Printf("Serving HTTP API at [%s]...", Socket)
+					errC <- (&http.Server{Handler: gateway.WithGRPC(srv, api)}).Serve(listener)
+					return
+				}
+
+				errC <- srv.Serve(listener)
 			}(errorC)
 
+			if HTTP != "" && HTTP != "socket" {
+				httpServer, err := listenHTTP(api, errorC)
+				if err != nil {
+					return err
+				}
+				defer httpServer.Close()
+			}
+
+			if Listen != "" {
+				remote, err := listenRemote(tomato, errorC)
+				if err != nil {
+					return err
+				}
+				defer remote.Stop()
+			}
+
 			signal.Notify(shutdownC, syscall.SIGINT, syscall.SIGTERM)
 
 			select {
@@ -293,6 +1308,43 @@ func serve() *cobra.Command {
 			}
 		},
 	}
+
+	cmd.Flags().StringVar(&LogFile, "log-file", LogFile, "file to write server logs to")
+	cmd.Flags().StringVar(&HistoryFile, "history-file", HistoryFile, "file to record finished sessions to")
+	cmd.Flags().StringVar(&HTTP, "http", HTTP, "also serve a JSON API, on the server's socket with \"socket\" or on unix:///path or tcp://host:port")
+	cmd.Flags().StringVar(&Listen, "listen", Listen, "also listen on tcp://host:port, over TLS and requiring --token")
+	cmd.Flags().StringVar(&TLSCert, "tls-cert", TLSCert, "certificate to serve --listen with (default self-signed)")
+	cmd.Flags().StringVar(&TLSKey, "tls-key", TLSKey, "private key for --tls-cert")
+	cmd.Flags().StringVar(&TasksFile, "tasks-file", TasksFile, "file to keep the todo list of tasks in")
+	cmd.Flags().StringVar(&StateFile, "state-file", StateFile, "file to persist the running timer to across restarts")
+	cmd.Flags().DurationVar(&server.Duration, "duration", server.Duration, "default length of a tomato")
+	cmd.Flags().DurationVar(&server.ShortBreak, "short-break", server.ShortBreak, "length of a short break")
+	cmd.Flags().DurationVar(&server.LongBreak, "long-break", server.LongBreak, "length of a long break")
+	cmd.Flags().IntVar(&server.LongBreakEvery, "long-break-every", server.LongBreakEvery, "how many tomatoes to complete before taking a long break")
+	cmd.Flags().BoolVar(&Notify, "notify", Notify, "send a desktop notification when a tomato or break completes")
+	cmd.Flags().StringVar(&NotifyTitle, "notify-title", NotifyTitle, "title of desktop notifications, {phase} and {next} are replaced")
+	cmd.Flags().StringVar(&NotifyBody, "notify-body", NotifyBody, "body of desktop notifications, {phase} and {next} are replaced")
+	cmd.Flags().StringVar(&DBusAddress, "dbus-address", DBusAddress, "D-Bus session bus to send notifications over (default $DBUS_SESSION_BUS_ADDRESS)")
+	cmd.Flags().StringArrayVar(&Hooks, "hook", Hooks, fmt.Sprintf("run a shell command on an event, as event=command, where event is one of %v", hooks.Events))
+	cmd.Flags().DurationVar(&HookTimeout, "hook-timeout", HookTimeout, "how long hooks may run for before being killed")
+
+	return cmd
+}
+
+// newHooks builds hooks from a list of event=command pairs.
+func newHooks(pairs []string, timeout time.Duration) (*hooks.Hooks, error) {
+	commands := map[string][]string{}
+
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("invalid hook %q, expected event=command", pair)
+		}
+
+		commands[parts[0]] = append(commands[parts[0]], parts[1])
+	}
+
+	return hooks.New(commands, timeout)
 }
 
 func up() *cobra.Command {
@@ -301,7 +1353,22 @@ func up() *cobra.Command {
 		Short: "Check whether the tomato server is up",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if !serverRunning() {
+			up := serverRunning()
+
+			result := struct {
+				Up bool `json:"up"`
+			}{Up: up}
+
+			err := report(result, func() {
+				if up {
+					log.Printf("the tomato server is up!")
+				}
+			}, func() {})
+			if err != nil {
+				return err
+			}
+
+			if !up {
 				return ErrNotRunning
 			}
 
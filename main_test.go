@@ -0,0 +1,412 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/CGA1123/tomato/client"
+	"github.com/CGA1123/tomato/clock"
+	"github.com/CGA1123/tomato/server"
+	"github.com/CGA1123/tomato/tomatotest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+func TestMain(m *testing.M) {
+	// Autostarting runs this test binary as `tomato server`.
+	if os.Getenv("TOMATO_TEST_MAIN") != "" {
+		main()
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+// setenv sets the environment variable key to value until the test finishes.
+func setenv(t *testing.T, key, value string) {
+	t.Helper()
+
+	old, ok := os.LookupEnv(key)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+
+	os.Setenv(key, value)
+}
+
+// run runs tomato with args against srv, returning what was printed to stdout
+// and what was logged.
+func run(t *testing.T, srv *tomatotest.Server, args ...string) (string, string, error) {
+	t.Helper()
+
+	return runWith(t, srv, nil, args...)
+}
+
+// runWith is run, with clients configured by opts. Without srv, commands
+// connect to a real server as they normally would.
+func runWith(t *testing.T, srv *tomatotest.Server, opts []client.Option, args ...string) (string, string, error) {
+	t.Helper()
+
+	output, format, quiet, tmpl := Output, Format, Quiet, outputTemplate
+	configFile, newClient, retry := ConfigFile, NewClient, Retry
+	stdout := os.Stdout
+	defer func() {
+		Output, Format, Quiet, outputTemplate = output, format, quiet, tmpl
+		ConfigFile, NewClient, Retry = configFile, newClient, retry
+		os.Stdout = stdout
+		log.SetOutput(os.Stderr)
+	}()
+
+	ConfigFile = filepath.Join(t.TempDir(), "config.toml")
+	if srv != nil {
+		NewClient = func() (*client.Client, error) {
+			return srv.Dial(append(callOptions(), opts...)...)
+		}
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("error creating pipe: %v", err)
+	}
+
+	printed := make(chan []byte)
+	go func() {
+		out, _ := ioutil.ReadAll(r)
+		printed <- out
+	}()
+
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	os.Stdout = w
+
+	cmd := Cmd()
+	cmd.SetArgs(args)
+	err = cmd.Execute()
+
+	w.Close()
+
+	return string(<-printed), logged.String(), err
+}
+
+func TestStartCommand(t *testing.T) {
+	srv := tomatotest.Start(t)
+
+	if _, _, err := run(t, srv, "start", "write tests", "--tag", "cli", "-d", "10m"); err != nil {
+		t.Fatalf("error running start: %v", err)
+	}
+
+	out, _, err := run(t, srv, "status", "-o", "json")
+	if err != nil {
+		t.Fatalf("error running status: %v", err)
+	}
+
+	var status struct {
+		State string   `json:"state"`
+		Phase string   `json:"phase"`
+		Label string   `json:"label"`
+		Tags  []string `json:"tags"`
+		Task  uint32   `json:"task"`
+	}
+	if err := json.Unmarshal([]byte(out), &status); err != nil {
+		t.Fatalf("error decoding status %q: %v", out, err)
+	}
+
+	if status.State != "running" || status.Phase != "work" || status.Label != "write tests" {
+		t.Fatalf("expected a running tomato labelled %q, got %+v", "write tests", status)
+	}
+
+	if len(status.Tags) != 1 || status.Tags[0] != "cli" {
+		t.Fatalf("expected the tomato to be tagged cli, got %v", status.Tags)
+	}
+
+	if _, _, err := run(t, srv, "start"); err == nil {
+		t.Fatalf("expected starting a second tomato to fail")
+	}
+}
+
+func TestRunningCommand(t *testing.T) {
+	srv := tomatotest.Start(t)
+
+	if _, _, err := run(t, srv, "running"); err != ErrNotRunning {
+		t.Fatalf("expected %v before starting, got %v", ErrNotRunning, err)
+	}
+
+	if _, _, err := run(t, srv, "start"); err != nil {
+		t.Fatalf("error running start: %v", err)
+	}
+
+	_, logged, err := run(t, srv, "running")
+	if err != nil {
+		t.Fatalf("expected running to succeed, got %v", err)
+	}
+
+	if !strings.Contains(logged, "you are working") {
+		t.Fatalf("expected to be told a tomato is running, got %q", logged)
+	}
+
+	if _, _, err := run(t, srv, "pause"); err != nil {
+		t.Fatalf("error running pause: %v", err)
+	}
+
+	if _, _, err := run(t, srv, "running"); err != ErrPaused {
+		t.Fatalf("expected %v once paused, got %v", ErrPaused, err)
+	}
+}
+
+func TestRemainingCommand(t *testing.T) {
+	fake := clock.NewFake(time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC))
+	srv := tomatotest.Start(t, server.WithClock(fake))
+
+	if _, _, err := run(t, srv, "start"); err != nil {
+		t.Fatalf("error running start: %v", err)
+	}
+
+	fake.Advance(10 * time.Minute)
+
+	out, _, err := run(t, srv, "remaining", "-q")
+	if err != nil {
+		t.Fatalf("error running remaining: %v", err)
+	}
+
+	if want := "15\n"; out != want {
+		t.Fatalf("expected %q minutes remaining, got %q", want, out)
+	}
+
+	out, _, err = run(t, srv, "remaining", "--format", "{{.Remaining}} of {{.Phase}}")
+	if err != nil {
+		t.Fatalf("error running remaining: %v", err)
+	}
+
+	if want := "15m0s of work\n"; out != want {
+		t.Fatalf("expected %q, got %q", want, out)
+	}
+}
+
+func TestTaskCommands(t *testing.T) {
+	srv := tomatotest.Start(t, server.WithTasks(server.NewTasks(filepath.Join(t.TempDir(), "tasks.json"))))
+
+	if _, _, err := run(t, srv, "task", "add", "write docs", "-e", "2"); err != nil {
+		t.Fatalf("error adding task: %v", err)
+	}
+
+	if _, _, err := run(t, srv, "start", "--task", "1"); err != nil {
+		t.Fatalf("error starting against task: %v", err)
+	}
+
+	out, _, err := run(t, srv, "status", "-o", "json")
+	if err != nil {
+		t.Fatalf("error running status: %v", err)
+	}
+
+	var status struct {
+		State string   `json:"state"`
+		Phase string   `json:"phase"`
+		Label string   `json:"label"`
+		Tags  []string `json:"tags"`
+		Task  uint32   `json:"task"`
+	}
+	if err := json.Unmarshal([]byte(out), &status); err != nil {
+		t.Fatalf("error decoding status %q: %v", out, err)
+	}
+
+	if status.Task != 1 || status.Label != "write docs" {
+		t.Fatalf("expected the tomato to be spent on task 1, got %+v", status)
+	}
+
+	if _, _, err := run(t, srv, "task", "done", "1"); err != nil {
+		t.Fatalf("error completing task: %v", err)
+	}
+
+	out, _, err = run(t, srv, "task", "list", "--all", "-o", "json")
+	if err != nil {
+		t.Fatalf("error listing tasks: %v", err)
+	}
+
+	var tasks []taskResult
+	if err := json.Unmarshal([]byte(out), &tasks); err != nil {
+		t.Fatalf("error decoding tasks %q: %v", out, err)
+	}
+
+	if len(tasks) != 1 || !tasks[0].Done || tasks[0].Estimate != 2 {
+		t.Fatalf("expected a single done task estimated at 2, got %+v", tasks)
+	}
+}
+
+func TestServerSettings(t *testing.T) {
+	duration, short, long, every, hookTimeout := server.Duration, server.ShortBreak, server.LongBreak, server.LongBreakEvery, HookTimeout
+	t.Cleanup(func() {
+		server.Duration, server.ShortBreak, server.LongBreak, server.LongBreakEvery, HookTimeout = duration, short, long, every, hookTimeout
+	})
+
+	srv := tomatotest.Start(t)
+
+	for _, args := range [][]string{
+		{"--duration", "10s"},
+		{"--duration", "5h"},
+		{"--short-break", "-5s"},
+		{"--long-break", "0s"},
+		{"--long-break-every", "0"},
+		{"--long-break-every", "-1"},
+		{"--hook-timeout", "0s"},
+	} {
+		// Invalid settings are refused before the server would start.
+		if _, _, err := run(t, srv, append([]string{"server"}, args...)...); err == nil {
+			t.Errorf("expected tomato server %v to fail", strings.Join(args, " "))
+		}
+
+		server.Duration, server.ShortBreak, server.LongBreak, server.LongBreakEvery, HookTimeout = duration, short, long, every, hookTimeout
+	}
+}
+
+func TestMissingConfig(t *testing.T) {
+	srv := tomatotest.Start(t)
+	missing := filepath.Join(t.TempDir(), "missing.toml")
+
+	if _, _, err := run(t, srv, "status", "--config", missing); err == nil {
+		t.Fatalf("expected a missing --config to fail")
+	}
+
+	// The default config file need not exist.
+	if _, _, err := run(t, srv, "status"); err != nil {
+		t.Fatalf("expected a missing default config to be ignored, got %v", err)
+	}
+}
+
+func TestSingleCall(t *testing.T) {
+	srv := tomatotest.Start(t)
+
+	if _, _, err := run(t, srv, "start"); err != nil {
+		t.Fatalf("error running start: %v", err)
+	}
+
+	// Each reports a single snapshot of the timer, rather than piecing one
+	// together from calls which could straddle a change.
+	for _, command := range []string{"remaining", "running", "status"} {
+		var methods []string
+		count := client.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			methods = append(methods, method)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		})
+
+		if _, _, err := runWith(t, srv, []client.Option{count}, command); err != nil {
+			t.Fatalf("error running %v: %v", command, err)
+		}
+
+		if len(methods) != 1 {
+			t.Errorf("expected %v to make a single call, made %v", command, methods)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	srv := tomatotest.Start(t)
+
+	for _, retry := range []bool{false, true} {
+		// The server is unavailable for the first two attempts, as if it
+		// were restarting.
+		attempts := 0
+		unavailable := client.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			attempts++
+			if attempts <= 2 {
+				return grpcstatus.Error(codes.Unavailable, "restarting")
+			}
+
+			return invoker(ctx, method, req, reply, cc, opts...)
+		})
+
+		args := []string{"status"}
+		if retry {
+			args = append(args, "--retry")
+		}
+
+		_, _, err := runWith(t, srv, []client.Option{unavailable}, args...)
+		if retry && err != nil {
+			t.Fatalf("expected --retry to outlast the server being unavailable, got %v", err)
+		}
+
+		if !retry && err == nil {
+			t.Fatalf("expected status to fail while the server is unavailable without --retry")
+		}
+	}
+}
+
+func TestAutostart(t *testing.T) {
+	socket, pidFile, logFile, historyFile, stateFile, tasksFile := Socket, PidFile, LogFile, HistoryFile, StateFile, TasksFile
+	autostart, notify, duration := Autostart, Notify, server.Duration
+	t.Cleanup(func() {
+		Socket, PidFile, LogFile, HistoryFile, StateFile, TasksFile = socket, pidFile, logFile, historyFile, stateFile, tasksFile
+		Autostart, Notify, server.Duration = autostart, notify, duration
+	})
+
+	// Keep the server's files out of the way, with no config file anywhere.
+	dir := t.TempDir()
+	setenv(t, "TOMATO_TEST_MAIN", "1")
+	setenv(t, "TOMATO_CONFIG", "")
+	setenv(t, "XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	setenv(t, "XDG_RUNTIME_DIR", filepath.Join(dir, "run"))
+	setenv(t, "XDG_STATE_HOME", filepath.Join(dir, "state"))
+	setenv(t, "TOMATO_SOCKET", filepath.Join(dir, "tomato.sock"))
+	setenv(t, "TOMATO_PID_FILE", filepath.Join(dir, "tomato.pid"))
+	setenv(t, "TOMATO_LOG_FILE", filepath.Join(dir, "tomato.log"))
+	setenv(t, "TOMATO_HISTORY_FILE", filepath.Join(dir, "history"))
+	setenv(t, "TOMATO_STATE_FILE", filepath.Join(dir, "state.json"))
+	setenv(t, "TOMATO_TASKS_FILE", filepath.Join(dir, "tasks.json"))
+	setenv(t, "TOMATO_NOTIFY", "false")
+
+	t.Run("refused", func(t *testing.T) {
+		setenv(t, "TOMATO_DURATION", "1s")
+
+		_, _, err := runWith(t, nil, nil, "--autostart", "status")
+		if err == nil || !strings.Contains(err.Error(), "exited during startup") {
+			t.Fatalf("expected the server to refuse to start, got %v", err)
+		}
+
+		// Why the server refused is left in its log.
+		logged, err := ioutil.ReadFile(filepath.Join(dir, "tomato.log"))
+		if err != nil {
+			t.Fatalf("error reading log: %v", err)
+		}
+
+		if !strings.Contains(string(logged), "duration must be between") {
+			t.Fatalf("expected the log to say why the server exited, got %q", logged)
+		}
+	})
+
+	t.Run("started", func(t *testing.T) {
+		t.Cleanup(func() {
+			pid, err := pidfileContents(filepath.Join(dir, "tomato.pid"))
+			if err != nil {
+				t.Errorf("error reading pidfile: %v", err)
+				return
+			}
+
+			syscall.Kill(pid, syscall.SIGTERM)
+			for deadline := time.Now().Add(5 * time.Second); pidIsRunning(pid) && time.Now().Before(deadline); {
+				time.Sleep(10 * time.Millisecond)
+			}
+		})
+
+		out, _, err := runWith(t, nil, nil, "--autostart", "status", "-o", "quiet")
+		if err != nil {
+			t.Fatalf("error autostarting the server: %v", err)
+		}
+
+		if out != "stopped\n" {
+			t.Fatalf("expected a fresh server to be stopped, got %q", out)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	destination = "org.freedesktop.Notifications"
+	path        = dbus.ObjectPath("/org/freedesktop/Notifications")
+	method      = destination + ".Notify"
+)
+
+// Notifier delivers notifications to the user.
+type Notifier interface {
+	Notify(title, body string) error
+}
+
+// DBus sends freedesktop notifications over D-Bus, see
+// https://specifications.freedesktop.org/notification-spec/latest/
+type DBus struct {
+	conn *dbus.Conn
+
+	// AppName is the name of the application sending the notification.
+	AppName string
+	// Icon is an icon name or file:// URI to display alongside the
+	// notification.
+	Icon string
+	// Timeout is how long, in milliseconds, the notification should be
+	// displayed for. -1 leaves it up to the notification server.
+	Timeout int32
+}
+
+// NewDBus connects to the D-Bus session bus at address, or to the user's
+// session bus when address is empty.
+func NewDBus(address string) (*DBus, error) {
+	var conn *dbus.Conn
+	var err error
+
+	if address == "" {
+		conn, err = dbus.ConnectSessionBus()
+	} else {
+		conn, err = dbus.Connect(address)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to D-Bus: %w", err)
+	}
+
+	return &DBus{conn: conn, AppName: "tomato", Timeout: -1}, nil
+}
+
+func (d *DBus) Notify(title, body string) error {
+	call := d.conn.Object(destination, path).Call(
+		method,
+		0,
+		d.AppName,
+		uint32(0),
+		d.Icon,
+		title,
+		body,
+		[]string{},
+		map[string]dbus.Variant{},
+		d.Timeout,
+	)
+
+	if call.Err != nil {
+		return fmt.Errorf("error sending notification: %w", call.Err)
+	}
+
+	return nil
+}
+
+func (d *DBus) Close() error {
+	return d.conn.Close()
+}
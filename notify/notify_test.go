@@ -0,0 +1,111 @@
+package notify_test
+
+import (
+	"bufio"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/CGA1123/tomato/notify"
+	"github.com/godbus/dbus/v5"
+)
+
+// startBus runs a private D-Bus session bus for the length of the test,
+// returning its address.
+func startBus(t *testing.T) string {
+	t.Helper()
+
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon is not installed")
+	}
+
+	socket := filepath.Join(t.TempDir(), "bus")
+	cmd := exec.Command(daemon, "--session", "--address=unix:path="+socket, "--nofork", "--print-address")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("error creating pipe: %v", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("error starting dbus-daemon: %v", err)
+	}
+
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatalf("error reading bus address: %v", err)
+	}
+
+	return strings.TrimSpace(address)
+}
+
+// notification is a call received by notifications.
+type notification struct {
+	AppName string
+	Title   string
+	Body    string
+	Timeout int32
+}
+
+// notifications is a fake notification server.
+type notifications chan notification
+
+func (n notifications) Notify(appName string, replacesID uint32, icon, summary, body string, actions []string, hints map[string]dbus.Variant, timeout int32) (uint32, *dbus.Error) {
+	n <- notification{AppName: appName, Title: summary, Body: body, Timeout: timeout}
+
+	return 1, nil
+}
+
+func TestDBus(t *testing.T) {
+	address := startBus(t)
+
+	conn, err := dbus.Connect(address)
+	if err != nil {
+		t.Fatalf("error connecting to bus: %v", err)
+	}
+	defer conn.Close()
+
+	received := make(notifications, 1)
+	if err := conn.Export(received, "/org/freedesktop/Notifications", "org.freedesktop.Notifications"); err != nil {
+		t.Fatalf("error exporting notification server: %v", err)
+	}
+
+	reply, err := conn.RequestName("org.freedesktop.Notifications", dbus.NameFlagDoNotQueue)
+	if err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatalf("error owning notification server name: %v (%v)", err, reply)
+	}
+
+	notifier, err := notify.NewDBus(address)
+	if err != nil {
+		t.Fatalf("error creating notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	if err := notifier.Notify("🍅 tomato", "tomato complete, time for a short break!"); err != nil {
+		t.Fatalf("error notifying: %v", err)
+	}
+
+	got := <-received
+	want := notification{AppName: "tomato", Title: "🍅 tomato", Body: "tomato complete, time for a short break!", Timeout: -1}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestDBusWithoutServer(t *testing.T) {
+	notifier, err := notify.NewDBus(startBus(t))
+	if err != nil {
+		t.Fatalf("error creating notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	if err := notifier.Notify("title", "body"); err == nil {
+		t.Fatalf("expected notifying without a notification server to fail")
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Output modes supported by --output.
+const (
+	OutputHuman    = "human"
+	OutputQuiet    = "quiet"
+	OutputJSON     = "json"
+	OutputTemplate = "template"
+)
+
+var (
+	Output = OutputHuman
+	Format = ""
+
+	outputTemplate *template.Template
+)
+
+// configureOutput validates the --output and --format flags, a --format on its
+// own implies --output template.
+func configureOutput() error {
+	if Format != "" && Output == OutputHuman {
+		Output = OutputTemplate
+	}
+
+	switch Output {
+	case OutputHuman, OutputJSON:
+	case OutputQuiet:
+		Quiet = true
+	case OutputTemplate:
+		if Format == "" {
+			return fmt.Errorf("--output template requires a --format")
+		}
+
+		t, err := template.New("format").Parse(Format)
+		if err != nil {
+			return fmt.Errorf("invalid --format: %w", err)
+		}
+
+		outputTemplate = t
+	default:
+		return fmt.Errorf("unknown --output %q, expected one of %v", Output, strings.Join([]string{OutputHuman, OutputQuiet, OutputJSON, OutputTemplate}, ", "))
+	}
+
+	return nil
+}
+
+// machineReadable returns whether output is meant to be parsed by another
+// program rather than read by a human.
+func machineReadable() bool {
+	return Output != OutputHuman
+}
+
+// report prints the result of a command, data is encoded as JSON or rendered
+// through the --format template while human and quiet output are left to the
+// given functions.
+func report(data interface{}, human func(), quiet func()) error {
+	switch Output {
+	case OutputJSON:
+		return json.NewEncoder(os.Stdout).Encode(data)
+	case OutputTemplate:
+		if err := outputTemplate.Execute(os.Stdout, data); err != nil {
+			return err
+		}
+
+		fmt.Println()
+	case OutputQuiet:
+		quiet()
+	default:
+		human()
+	}
+
+	return nil
+}
+
+// duration is a time.Duration which prints rounded to the second in
+// templates, and as a number of seconds in JSON.
+type duration time.Duration
+
+func (d duration) String() string {
+	return time.Duration(d).Round(time.Second).String()
+}
+
+func (d duration) Minutes() float64 {
+	return time.Duration(d).Minutes()
+}
+
+func (d duration) Seconds() float64 {
+	return time.Duration(d).Seconds()
+}
+
+func (d duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).Seconds())
+}
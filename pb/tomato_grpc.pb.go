@@ -11,7 +11,6 @@ import (
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
-	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -23,10 +22,25 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TomatoServiceClient interface {
-	Start(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*timestamppb.Timestamp, error)
-	Stop(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*durationpb.Duration, error)
+	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*timestamppb.Timestamp, error)
+	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*durationpb.Duration, error)
 	Remaining(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*durationpb.Duration, error)
-	Running(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.BoolValue, error)
+	Running(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RunningResponse, error)
+	Phase(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PhaseResponse, error)
+	// Status returns a consistent snapshot of the whole timer in one call.
+	Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TomatoStatus, error)
+	Pause(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*durationpb.Duration, error)
+	Resume(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*timestamppb.Timestamp, error)
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (TomatoService_WatchClient, error)
+	// Interrupt records an interruption against the current tomato, without
+	// stopping it.
+	Interrupt(ctx context.Context, in *InterruptRequest, opts ...grpc.CallOption) (*Interruption, error)
+	AddTask(ctx context.Context, in *AddTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	CompleteTask(ctx context.Context, in *CompleteTaskRequest, opts ...grpc.CallOption) (*Task, error)
+	EstimateTask(ctx context.Context, in *EstimateTaskRequest, opts ...grpc.CallOption) (*Task, error)
 }
 
 type tomatoServiceClient struct {
@@ -37,7 +51,7 @@ func NewTomatoServiceClient(cc grpc.ClientConnInterface) TomatoServiceClient {
 	return &tomatoServiceClient{cc}
 }
 
-func (c *tomatoServiceClient) Start(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*timestamppb.Timestamp, error) {
+func (c *tomatoServiceClient) Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*timestamppb.Timestamp, error) {
 	out := new(timestamppb.Timestamp)
 	err := c.cc.Invoke(ctx, "/tomato.pb.TomatoService/Start", in, out, opts...)
 	if err != nil {
@@ -46,7 +60,7 @@ func (c *tomatoServiceClient) Start(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
-func (c *tomatoServiceClient) Stop(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*durationpb.Duration, error) {
+func (c *tomatoServiceClient) Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*durationpb.Duration, error) {
 	out := new(durationpb.Duration)
 	err := c.cc.Invoke(ctx, "/tomato.pb.TomatoService/Stop", in, out, opts...)
 	if err != nil {
@@ -64,8 +78,8 @@ func (c *tomatoServiceClient) Remaining(ctx context.Context, in *emptypb.Empty,
 	return out, nil
 }
 
-func (c *tomatoServiceClient) Running(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.BoolValue, error) {
-	out := new(wrapperspb.BoolValue)
+func (c *tomatoServiceClient) Running(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RunningResponse, error) {
+	out := new(RunningResponse)
 	err := c.cc.Invoke(ctx, "/tomato.pb.TomatoService/Running", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -73,14 +87,160 @@ func (c *tomatoServiceClient) Running(ctx context.Context, in *emptypb.Empty, op
 	return out, nil
 }
 
+func (c *tomatoServiceClient) Phase(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PhaseResponse, error) {
+	out := new(PhaseResponse)
+	err := c.cc.Invoke(ctx, "/tomato.pb.TomatoService/Phase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tomatoServiceClient) Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TomatoStatus, error) {
+	out := new(TomatoStatus)
+	err := c.cc.Invoke(ctx, "/tomato.pb.TomatoService/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tomatoServiceClient) Pause(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*durationpb.Duration, error) {
+	out := new(durationpb.Duration)
+	err := c.cc.Invoke(ctx, "/tomato.pb.TomatoService/Pause", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tomatoServiceClient) Resume(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*timestamppb.Timestamp, error) {
+	out := new(timestamppb.Timestamp)
+	err := c.cc.Invoke(ctx, "/tomato.pb.TomatoService/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tomatoServiceClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, "/tomato.pb.TomatoService/History", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tomatoServiceClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, "/tomato.pb.TomatoService/Stats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tomatoServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (TomatoService_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TomatoService_ServiceDesc.Streams[0], "/tomato.pb.TomatoService/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tomatoServiceWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TomatoService_WatchClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type tomatoServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *tomatoServiceWatchClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tomatoServiceClient) Interrupt(ctx context.Context, in *InterruptRequest, opts ...grpc.CallOption) (*Interruption, error) {
+	out := new(Interruption)
+	err := c.cc.Invoke(ctx, "/tomato.pb.TomatoService/Interrupt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tomatoServiceClient) AddTask(ctx context.Context, in *AddTaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	out := new(Task)
+	err := c.cc.Invoke(ctx, "/tomato.pb.TomatoService/AddTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tomatoServiceClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, "/tomato.pb.TomatoService/ListTasks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tomatoServiceClient) CompleteTask(ctx context.Context, in *CompleteTaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	out := new(Task)
+	err := c.cc.Invoke(ctx, "/tomato.pb.TomatoService/CompleteTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tomatoServiceClient) EstimateTask(ctx context.Context, in *EstimateTaskRequest, opts ...grpc.CallOption) (*Task, error) {
+	out := new(Task)
+	err := c.cc.Invoke(ctx, "/tomato.pb.TomatoService/EstimateTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TomatoServiceServer is the server API for TomatoService service.
 // All implementations must embed UnimplementedTomatoServiceServer
 // for forward compatibility
 type TomatoServiceServer interface {
-	Start(context.Context, *emptypb.Empty) (*timestamppb.Timestamp, error)
-	Stop(context.Context, *emptypb.Empty) (*durationpb.Duration, error)
+	Start(context.Context, *StartRequest) (*timestamppb.Timestamp, error)
+	Stop(context.Context, *StopRequest) (*durationpb.Duration, error)
 	Remaining(context.Context, *emptypb.Empty) (*durationpb.Duration, error)
-	Running(context.Context, *emptypb.Empty) (*wrapperspb.BoolValue, error)
+	Running(context.Context, *emptypb.Empty) (*RunningResponse, error)
+	Phase(context.Context, *emptypb.Empty) (*PhaseResponse, error)
+	// Status returns a consistent snapshot of the whole timer in one call.
+	Status(context.Context, *emptypb.Empty) (*TomatoStatus, error)
+	Pause(context.Context, *emptypb.Empty) (*durationpb.Duration, error)
+	Resume(context.Context, *emptypb.Empty) (*timestamppb.Timestamp, error)
+	History(context.Context, *HistoryRequest) (*HistoryResponse, error)
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+	Watch(*WatchRequest, TomatoService_WatchServer) error
+	// Interrupt records an interruption against the current tomato, without
+	// stopping it.
+	Interrupt(context.Context, *InterruptRequest) (*Interruption, error)
+	AddTask(context.Context, *AddTaskRequest) (*Task, error)
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	CompleteTask(context.Context, *CompleteTaskRequest) (*Task, error)
+	EstimateTask(context.Context, *EstimateTaskRequest) (*Task, error)
 	mustEmbedUnimplementedTomatoServiceServer()
 }
 
@@ -88,18 +248,54 @@ type TomatoServiceServer interface {
 type UnimplementedTomatoServiceServer struct {
 }
 
-func (UnimplementedTomatoServiceServer) Start(context.Context, *emptypb.Empty) (*timestamppb.Timestamp, error) {
+func (UnimplementedTomatoServiceServer) Start(context.Context, *StartRequest) (*timestamppb.Timestamp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
 }
-func (UnimplementedTomatoServiceServer) Stop(context.Context, *emptypb.Empty) (*durationpb.Duration, error) {
+func (UnimplementedTomatoServiceServer) Stop(context.Context, *StopRequest) (*durationpb.Duration, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
 func (UnimplementedTomatoServiceServer) Remaining(context.Context, *emptypb.Empty) (*durationpb.Duration, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Remaining not implemented")
 }
-func (UnimplementedTomatoServiceServer) Running(context.Context, *emptypb.Empty) (*wrapperspb.BoolValue, error) {
+func (UnimplementedTomatoServiceServer) Running(context.Context, *emptypb.Empty) (*RunningResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Running not implemented")
 }
+func (UnimplementedTomatoServiceServer) Phase(context.Context, *emptypb.Empty) (*PhaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Phase not implemented")
+}
+func (UnimplementedTomatoServiceServer) Status(context.Context, *emptypb.Empty) (*TomatoStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedTomatoServiceServer) Pause(context.Context, *emptypb.Empty) (*durationpb.Duration, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (UnimplementedTomatoServiceServer) Resume(context.Context, *emptypb.Empty) (*timestamppb.Timestamp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (UnimplementedTomatoServiceServer) History(context.Context, *HistoryRequest) (*HistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+func (UnimplementedTomatoServiceServer) Stats(context.Context, *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (UnimplementedTomatoServiceServer) Watch(*WatchRequest, TomatoService_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedTomatoServiceServer) Interrupt(context.Context, *InterruptRequest) (*Interruption, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Interrupt not implemented")
+}
+func (UnimplementedTomatoServiceServer) AddTask(context.Context, *AddTaskRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTask not implemented")
+}
+func (UnimplementedTomatoServiceServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedTomatoServiceServer) CompleteTask(context.Context, *CompleteTaskRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteTask not implemented")
+}
+func (UnimplementedTomatoServiceServer) EstimateTask(context.Context, *EstimateTaskRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateTask not implemented")
+}
 func (UnimplementedTomatoServiceServer) mustEmbedUnimplementedTomatoServiceServer() {}
 
 // UnsafeTomatoServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -114,7 +310,7 @@ func RegisterTomatoServiceServer(s grpc.ServiceRegistrar, srv TomatoServiceServe
 }
 
 func _TomatoService_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(StartRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -126,13 +322,13 @@ func _TomatoService_Start_Handler(srv interface{}, ctx context.Context, dec func
 		FullMethod: "/tomato.pb.TomatoService/Start",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TomatoServiceServer).Start(ctx, req.(*emptypb.Empty))
+		return srv.(TomatoServiceServer).Start(ctx, req.(*StartRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _TomatoService_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(StopRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -144,7 +340,7 @@ func _TomatoService_Stop_Handler(srv interface{}, ctx context.Context, dec func(
 		FullMethod: "/tomato.pb.TomatoService/Stop",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TomatoServiceServer).Stop(ctx, req.(*emptypb.Empty))
+		return srv.(TomatoServiceServer).Stop(ctx, req.(*StopRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -185,6 +381,225 @@ func _TomatoService_Running_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TomatoService_Phase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TomatoServiceServer).Phase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomato.pb.TomatoService/Phase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TomatoServiceServer).Phase(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TomatoService_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TomatoServiceServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomato.pb.TomatoService/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TomatoServiceServer).Status(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TomatoService_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TomatoServiceServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomato.pb.TomatoService/Pause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TomatoServiceServer).Pause(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TomatoService_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TomatoServiceServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomato.pb.TomatoService/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TomatoServiceServer).Resume(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TomatoService_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TomatoServiceServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomato.pb.TomatoService/History",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TomatoServiceServer).History(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TomatoService_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TomatoServiceServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomato.pb.TomatoService/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TomatoServiceServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TomatoService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TomatoServiceServer).Watch(m, &tomatoServiceWatchServer{stream})
+}
+
+type TomatoService_WatchServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type tomatoServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *tomatoServiceWatchServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TomatoService_Interrupt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InterruptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TomatoServiceServer).Interrupt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomato.pb.TomatoService/Interrupt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TomatoServiceServer).Interrupt(ctx, req.(*InterruptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TomatoService_AddTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TomatoServiceServer).AddTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomato.pb.TomatoService/AddTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TomatoServiceServer).AddTask(ctx, req.(*AddTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TomatoService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TomatoServiceServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomato.pb.TomatoService/ListTasks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TomatoServiceServer).ListTasks(ctx, req.(*ListTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TomatoService_CompleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TomatoServiceServer).CompleteTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomato.pb.TomatoService/CompleteTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TomatoServiceServer).CompleteTask(ctx, req.(*CompleteTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TomatoService_EstimateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TomatoServiceServer).EstimateTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tomato.pb.TomatoService/EstimateTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TomatoServiceServer).EstimateTask(ctx, req.(*EstimateTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TomatoService_ServiceDesc is the grpc.ServiceDesc for TomatoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -208,7 +623,57 @@ var TomatoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Running",
 			Handler:    _TomatoService_Running_Handler,
 		},
+		{
+			MethodName: "Phase",
+			Handler:    _TomatoService_Phase_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _TomatoService_Status_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _TomatoService_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _TomatoService_Resume_Handler,
+		},
+		{
+			MethodName: "History",
+			Handler:    _TomatoService_History_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _TomatoService_Stats_Handler,
+		},
+		{
+			MethodName: "Interrupt",
+			Handler:    _TomatoService_Interrupt_Handler,
+		},
+		{
+			MethodName: "AddTask",
+			Handler:    _TomatoService_AddTask_Handler,
+		},
+		{
+			MethodName: "ListTasks",
+			Handler:    _TomatoService_ListTasks_Handler,
+		},
+		{
+			MethodName: "CompleteTask",
+			Handler:    _TomatoService_CompleteTask_Handler,
+		},
+		{
+			MethodName: "EstimateTask",
+			Handler:    _TomatoService_EstimateTask_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _TomatoService_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "tomato.proto",
 }
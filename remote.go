@@ -0,0 +1,200 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/CGA1123/tomato/client"
+	"github.com/CGA1123/tomato/config"
+	"github.com/CGA1123/tomato/gateway"
+	"github.com/CGA1123/tomato/pb"
+	"github.com/CGA1123/tomato/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var (
+	HTTP    = ""
+	Listen  = ""
+	TLSCert = ""
+	TLSKey  = ""
+	Token   = ""
+	Addr    = ""
+	CAFile  = ""
+)
+
+// listenRemote starts serving tomato over TLS on the tcp://host:port address
+// in Listen, requiring Token on every call. Without a TLSCert and TLSKey a
+// self-signed certificate is generated and kept in the state directory.
+func listenRemote(tomato pb.TomatoServiceServer, errC chan<- error) (*grpc.Server, error) {
+	if !strings.HasPrefix(Listen, "tcp://") {
+		return nil, fmt.Errorf("invalid --listen %q, expected tcp://host:port", Listen)
+	}
+
+	if Token == "" {
+		return nil, fmt.Errorf("--listen requires a --token")
+	}
+
+	addr := strings.TrimPrefix(Listen, "tcp://")
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --listen %q: %w", Listen, err)
+	}
+
+	cert, err := certificate(host)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("error listening on %v: %w", addr, err)
+	}
+
+	opts := append(
+		server.TokenAuth(Token),
+		grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}})),
+	)
+
+	srv := grpc.NewServer(opts...)
+	pb.RegisterTomatoServiceServer(srv, tomato)
+
+	log.Printf("Starting server at [%s]...", Listen)
+	go func() {
+		errC <- srv.Serve(listener)
+	}()
+
+	return srv, nil
+}
+
+// listenHTTP starts serving the JSON API on its own listener, HTTP being
+// either unix:///path/to/socket or tcp://host:port. Over tcp it is served with
+// TLS and requires Token, as with listenRemote.
+func listenHTTP(api http.Handler, errC chan<- error) (*http.Server, error) {
+	var listener net.Listener
+	var err error
+	srv := &http.Server{Handler: api}
+
+	switch {
+	case strings.HasPrefix(HTTP, "unix://"):
+		path := strings.TrimPrefix(HTTP, "unix://")
+		os.Remove(path)
+
+		listener, err = net.Listen("unix", path)
+	case strings.HasPrefix(HTTP, "tcp://"):
+		if Token == "" {
+			return nil, fmt.Errorf("--http on tcp requires a --token")
+		}
+
+		addr := strings.TrimPrefix(HTTP, "tcp://")
+		host, _, splitErr := net.SplitHostPort(addr)
+		if splitErr != nil {
+			return nil, fmt.Errorf("invalid --http %q: %w", HTTP, splitErr)
+		}
+
+		cert, certErr := certificate(host)
+		if certErr != nil {
+			return nil, certErr
+		}
+
+		srv.Handler = gateway.RequireToken(Token, api)
+		srv.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+
+		listener, err = net.Listen("tcp", addr)
+		if err == nil {
+			listener = tls.NewListener(listener, srv.TLSConfig)
+		}
+	default:
+		return nil, fmt.Errorf("invalid --http %q, expected socket, unix:///path or tcp://host:port", HTTP)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error listening on %v: %w", HTTP, err)
+	}
+
+	log.Printf("Serving HTTP API at [%s]...", HTTP)
+	go func() {
+		errC <- srv.Serve(listener)
+	}()
+
+	return srv, nil
+}
+
+// certificate loads the TLS certificate to serve with, either the one given
+// or a self-signed one valid for host and the local machine.
+func certificate(host string) (tls.Certificate, error) {
+	if TLSCert != "" || TLSKey != "" {
+		if TLSCert == "" || TLSKey == "" {
+			return tls.Certificate{}, fmt.Errorf("--tls-cert and --tls-key must be given together")
+		}
+
+		cert, err := tls.LoadX509KeyPair(TLSCert, TLSKey)
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("error loading certificate: %w", err)
+		}
+
+		return cert, nil
+	}
+
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if ip := net.ParseIP(host); host != "" && (ip == nil || !ip.IsUnspecified()) {
+		hosts = append(hosts, host)
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		hosts = append(hosts, hostname)
+	}
+
+	dir := config.StateDir()
+	if err := config.EnsureDir(dir); err != nil {
+		return tls.Certificate{}, err
+	}
+
+	certFile := filepath.Join(dir, "cert.pem")
+	log.Printf("Using self-signed certificate: %v", certFile)
+
+	cert, err := server.SelfSignedCertificate(certFile, filepath.Join(dir, "key.pem"), hosts)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("error loading self-signed certificate: %w", err)
+	}
+
+	return cert, nil
+}
+
+// dialTarget returns where the client should connect and how, Addr over TLS
+// if set and the local socket otherwise.
+func dialTarget() (string, []client.Option, error) {
+	if Addr == "" {
+		return Socket, nil, nil
+	}
+
+	config := &tls.Config{}
+	if CAFile != "" {
+		pem, err := ioutil.ReadFile(CAFile)
+		if err != nil {
+			return "", nil, fmt.Errorf("error reading --ca-file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return "", nil, fmt.Errorf("no certificates found in --ca-file %v", CAFile)
+		}
+
+		config.RootCAs = pool
+	}
+
+	opts := []client.Option{client.WithTLS(config)}
+	if Token != "" {
+		opts = append(opts, client.WithToken(Token))
+	}
+
+	return Addr, opts, nil
+}
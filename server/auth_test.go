@@ -0,0 +1,169 @@
+package server_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/CGA1123/tomato/client"
+	"github.com/CGA1123/tomato/pb"
+	"github.com/CGA1123/tomato/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const token = "s3cret"
+
+// bearer sends a token, however wrong, without client.WithToken's checks.
+type bearer string
+
+func (b bearer) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(b)}, nil
+}
+
+func (b bearer) RequireTransportSecurity() bool {
+	return true
+}
+
+// listenTLS serves a server requiring token over TLS in memory, using a
+// self-signed certificate for localhost. It returns a dialer reaching it and
+// a TLS config trusting its certificate.
+func listenTLS(t *testing.T) (func(context.Context, string) (net.Conn, error), *tls.Config) {
+	t.Helper()
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+
+	cert, err := server.SelfSignedCertificate(certFile, filepath.Join(dir, "key.pem"), []string{"localhost"})
+	if err != nil {
+		t.Fatalf("error generating certificate: %v", err)
+	}
+
+	pem, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		t.Fatalf("error reading certificate: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		t.Fatalf("no certificate in %v", certFile)
+	}
+
+	lis := bufconn.Listen(1024 * 1024)
+	tomato := server.New()
+	srv := grpc.NewServer(append(
+		server.TokenAuth(token),
+		grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}})),
+	)...)
+	pb.RegisterTomatoServiceServer(srv, tomato)
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+
+	t.Cleanup(func() {
+		srv.Stop()
+		tomato.Close()
+	})
+
+	dial := func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.Dial()
+	}
+
+	return dial, &tls.Config{RootCAs: pool, ServerName: "localhost"}
+}
+
+func TestTokenAuth(t *testing.T) {
+	dial, config := listenTLS(t)
+
+	tests := []struct {
+		name  string
+		token string
+		code  codes.Code
+	}{
+		{"missing", "", codes.Unauthenticated},
+		{"wrong", "guess", codes.Unauthenticated},
+		{"right", token, codes.OK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := []grpc.DialOption{
+				grpc.WithContextDialer(dial),
+				grpc.WithTransportCredentials(credentials.NewTLS(config)),
+			}
+
+			if test.token != "" {
+				opts = append(opts, grpc.WithPerRPCCredentials(bearer(test.token)))
+			}
+
+			conn, err := grpc.Dial("passthrough:///bufconn", opts...)
+			if err != nil {
+				t.Fatalf("error dialing: %v", err)
+			}
+			defer conn.Close()
+
+			tomato := pb.NewTomatoServiceClient(conn)
+
+			_, err = tomato.Status(context.Background(), &emptypb.Empty{})
+			if got := grpcstatus.Code(err); got != test.code {
+				t.Errorf("expected Status to return %v, got %v", test.code, err)
+			}
+
+			// Once started there are ticks to watch.
+			_, err = tomato.Start(context.Background(), &pb.StartRequest{})
+			if got := grpcstatus.Code(err); got != test.code {
+				t.Errorf("expected Start to return %v, got %v", test.code, err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			stream, err := tomato.Watch(ctx, &pb.WatchRequest{TickInterval: durationpb.New(server.MinTickInterval)})
+			if err == nil {
+				_, err = stream.Recv()
+			}
+
+			if got := grpcstatus.Code(err); got != test.code {
+				t.Errorf("expected Watch to return %v, got %v", test.code, err)
+			}
+		})
+	}
+}
+
+func TestClientToken(t *testing.T) {
+	dial, config := listenTLS(t)
+
+	c, err := client.New("bufconn", client.WithDialer(dial), client.WithTLS(config), client.WithToken(token))
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	defer c.Close()
+
+	if _, err := c.Status(); err != nil {
+		t.Fatalf("expected the right token to be accepted, got %v", err)
+	}
+
+	wrong, err := client.New("bufconn", client.WithDialer(dial), client.WithTLS(config), client.WithToken("guess"))
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	defer wrong.Close()
+
+	if _, err := wrong.Status(); grpcstatus.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected the wrong token to be refused, got %v", err)
+	}
+
+	if _, err := client.New("bufconn", client.WithDialer(dial), client.WithToken(token)); err == nil {
+		t.Fatalf("expected a token without TLS to be refused")
+	}
+}
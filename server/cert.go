@@ -0,0 +1,119 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net"
+	"os"
+	"time"
+)
+
+// CertificateValidity is how long a generated self-signed certificate is
+// valid for.
+var CertificateValidity = 365 * 24 * time.Hour
+
+// SelfSignedCertificate loads the certificate and key at certFile and
+// keyFile, generating a self-signed certificate for hosts (names or IP
+// addresses) and writing it there first if either does not exist. An existing
+// certificate which has expired, or does not cover every one of hosts, is
+// replaced by a new one.
+func SelfSignedCertificate(certFile, keyFile string, hosts []string) (tls.Certificate, error) {
+	_, certErr := os.Stat(certFile)
+	_, keyErr := os.Stat(keyFile)
+	if certErr == nil && keyErr == nil {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return tls.Certificate{}, err
+		}
+
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("error parsing certificate: %w", err)
+		}
+
+		problem := unusable(leaf, hosts, time.Now())
+		if problem == "" {
+			return cert, nil
+		}
+
+		log.Printf("Regenerating %v as %v, clients trusting the old certificate need the new one", certFile, problem)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("error generating key: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("error generating serial number: %w", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "tomato"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(CertificateValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("error creating certificate: %w", err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("error encoding key: %w", err)
+	}
+
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	if err := ioutil.WriteFile(keyFile, keyPem, 0600); err != nil {
+		return tls.Certificate{}, fmt.Errorf("error writing key: %w", err)
+	}
+
+	if err := ioutil.WriteFile(certFile, certPem, 0644); err != nil {
+		return tls.Certificate{}, fmt.Errorf("error writing certificate: %w", err)
+	}
+
+	return tls.X509KeyPair(certPem, keyPem)
+}
+
+// unusable returns why leaf can't be served for hosts at now, or an empty
+// string if it can.
+func unusable(leaf *x509.Certificate, hosts []string, now time.Time) string {
+	if now.After(leaf.NotAfter) {
+		return fmt.Sprintf("it expired at %v", leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	for _, host := range hosts {
+		if err := leaf.VerifyHostname(host); err != nil {
+			return fmt.Sprintf("it is not valid for %v", host)
+		}
+	}
+
+	return ""
+}
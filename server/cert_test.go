@@ -0,0 +1,67 @@
+package server_test
+
+import (
+	"bytes"
+	"crypto/x509"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CGA1123/tomato/server"
+)
+
+func TestSelfSignedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	generate := func(hosts ...string) (*x509.Certificate, []byte) {
+		t.Helper()
+
+		cert, err := server.SelfSignedCertificate(certFile, keyFile, hosts)
+		if err != nil {
+			t.Fatalf("error loading certificate: %v", err)
+		}
+
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			t.Fatalf("error parsing certificate: %v", err)
+		}
+
+		pem, err := ioutil.ReadFile(certFile)
+		if err != nil {
+			t.Fatalf("error reading certificate: %v", err)
+		}
+
+		return leaf, pem
+	}
+
+	validity := server.CertificateValidity
+	t.Cleanup(func() { server.CertificateValidity = validity })
+
+	// An expired certificate is replaced.
+	server.CertificateValidity = -2 * time.Hour
+	expired, _ := generate("localhost")
+
+	server.CertificateValidity = validity
+	renewed, pem := generate("localhost")
+
+	if renewed.SerialNumber.Cmp(expired.SerialNumber) == 0 || !renewed.NotAfter.After(time.Now()) {
+		t.Fatalf("expected an expired certificate to be replaced, still valid until %v", renewed.NotAfter)
+	}
+
+	// A valid one is kept as it is.
+	if kept, again := generate("localhost"); kept.SerialNumber.Cmp(renewed.SerialNumber) != 0 || !bytes.Equal(again, pem) {
+		t.Fatalf("expected a valid certificate to be kept")
+	}
+
+	// One which doesn't cover a new host is replaced by one that does.
+	moved, _ := generate("localhost", "tomato.example.com")
+	if moved.SerialNumber.Cmp(renewed.SerialNumber) == 0 {
+		t.Fatalf("expected a certificate not covering every host to be replaced")
+	}
+
+	if err := moved.VerifyHostname("tomato.example.com"); err != nil {
+		t.Fatalf("expected the new certificate to cover the new host: %v", err)
+	}
+}
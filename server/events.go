@@ -0,0 +1,49 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/CGA1123/tomato/pb"
+)
+
+// broker fans events out to every subscriber. Subscribers which are not
+// keeping up have events dropped rather than blocking the server. A
+// subscriber's channel is closed once it unsubscribes.
+type broker struct {
+	mut         sync.Mutex
+	subscribers map[chan *pb.Event]struct{}
+}
+
+func (b *broker) subscribe() (<-chan *pb.Event, func()) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	if b.subscribers == nil {
+		b.subscribers = map[chan *pb.Event]struct{}{}
+	}
+
+	events := make(chan *pb.Event, 16)
+	b.subscribers[events] = struct{}{}
+
+	return events, func() {
+		b.mut.Lock()
+		defer b.mut.Unlock()
+
+		if _, ok := b.subscribers[events]; ok {
+			delete(b.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+func (b *broker) publish(event *pb.Event) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	for events := range b.subscribers {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/CGA1123/tomato/pb"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// History is an append-only log of finished sessions, stored on disk as one
+// JSON encoded pb.Session per line.
+type History struct {
+	mut  sync.Mutex
+	path string
+}
+
+func NewHistory(path string) *History {
+	return &History{path: path}
+}
+
+// Append records a finished session.
+func (h *History) Append(session *pb.Session) error {
+	line, err := protojson.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("error encoding session: %w", err)
+	}
+
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("error opening history: %w", err)
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("error writing history: %w", err)
+	}
+
+	return f.Close()
+}
+
+// Sessions returns the sessions started within [since, until), a zero time
+// leaves that end of the range unbounded.
+func (h *History) Sessions(since, until time.Time) ([]*pb.Session, error) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	f, err := os.Open(h.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening history: %w", err)
+	}
+	defer f.Close()
+
+	var sessions []*pb.Session
+
+	// Lines are read whole, however long, rather than with a bufio.Scanner
+	// which gives up on any over 64KiB.
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			session := &pb.Session{}
+			if err := protojson.Unmarshal(line, session); err != nil {
+				return nil, fmt.Errorf("error decoding history: %w", err)
+			}
+
+			if within(session.GetStartedAt().AsTime(), since, until) {
+				sessions = append(sessions, session)
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading history: %w", err)
+		}
+	}
+
+	return sessions, nil
+}
+
+// within returns whether t is within [since, until), a zero time leaving that
+// end of the range unbounded.
+func within(t, since, until time.Time) bool {
+	if !since.IsZero() && t.Before(since) {
+		return false
+	}
+
+	return until.IsZero() || t.Before(until)
+}
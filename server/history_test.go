@@ -0,0 +1,54 @@
+package server_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CGA1123/tomato/pb"
+	"github.com/CGA1123/tomato/server"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestHistoryLongEntry(t *testing.T) {
+	history := server.NewHistory(filepath.Join(t.TempDir(), "history"))
+	started := time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	// Written before labels were capped, or by hand, and well over the 64KiB
+	// a bufio.Scanner would read.
+	label := strings.Repeat("🍅", 50*1024)
+	for i, l := range []string{"short", label, "after"} {
+		session := &pb.Session{
+			Phase:     pb.Phase_PHASE_WORK,
+			StartedAt: timestamppb.New(started.Add(time.Duration(i) * time.Hour)),
+			Planned:   durationpb.New(server.Duration),
+			Actual:    durationpb.New(server.Duration),
+			Outcome:   pb.Outcome_OUTCOME_COMPLETED,
+			Label:     l,
+		}
+
+		if err := history.Append(session); err != nil {
+			t.Fatalf("error appending session: %v", err)
+		}
+	}
+
+	got := sessions(t, history)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 sessions, got %d", len(got))
+	}
+
+	if got[1].GetLabel() != label || got[2].GetLabel() != "after" {
+		t.Fatalf("expected the long entry and the one after it to be read back whole")
+	}
+
+	within, err := history.Sessions(started.Add(time.Hour), started.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("error reading history: %v", err)
+	}
+
+	if len(within) != 1 || within[0].GetLabel() != label {
+		t.Fatalf("expected only the long entry within [1h, 2h), got %d sessions", len(within))
+	}
+}
@@ -0,0 +1,75 @@
+package server_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/CGA1123/tomato/clock"
+	"github.com/CGA1123/tomato/pb"
+	"github.com/CGA1123/tomato/server"
+)
+
+// notifier records notifications, taking delay to send each.
+type notifier struct {
+	delay time.Duration
+
+	mut  sync.Mutex
+	sent []string
+}
+
+func (n *notifier) Notify(title, body string) error {
+	time.Sleep(n.delay)
+
+	n.mut.Lock()
+	defer n.mut.Unlock()
+
+	n.sent = append(n.sent, title+": "+body)
+
+	return nil
+}
+
+func TestNotify(t *testing.T) {
+	fake := clock.NewFake(time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC))
+	n := &notifier{delay: 10 * time.Millisecond}
+	s := server.New(server.WithClock(fake), server.WithNotifier(n, "{phase} done", "{phase} complete, time for a {next}!"))
+
+	for i := 0; i < server.LongBreakEvery; i++ {
+		if _, err := s.Start(context.Background(), &pb.StartRequest{}); err != nil {
+			t.Fatalf("error starting: %v", err)
+		}
+
+		fake.Advance(server.Duration)
+		fake.Advance(server.LongBreak)
+	}
+
+	// Close waits for notifications still being sent.
+	s.Close()
+
+	n.mut.Lock()
+	defer n.mut.Unlock()
+
+	// Notifications are sent concurrently, so may arrive in any order.
+	got := map[string]int{}
+	for _, sent := range n.sent {
+		got[sent]++
+	}
+
+	want := map[string]int{
+		"tomato done: tomato complete, time for a short break!":      server.LongBreakEvery - 1,
+		"short break done: short break complete, time for a tomato!": server.LongBreakEvery - 1,
+		"tomato done: tomato complete, time for a long break!":       1,
+		"long break done: long break complete, time for a tomato!":   1,
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected notifications %v, got %v", want, got)
+	}
+
+	for notification, count := range want {
+		if got[notification] != count {
+			t.Errorf("expected %q %d time(s), got %d", notification, count, got[notification])
+		}
+	}
+}
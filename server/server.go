@@ -2,87 +2,859 @@ package server
 
 import (
 	"context"
-	"fmt"
+	"log"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/CGA1123/tomato/clock"
+	"github.com/CGA1123/tomato/hooks"
+	"github.com/CGA1123/tomato/notify"
 	"github.com/CGA1123/tomato/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
-	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 var (
-	Duration = 25 * time.Minute
+	Duration       = 25 * time.Minute
+	ShortBreak     = 5 * time.Minute
+	LongBreak      = 15 * time.Minute
+	LongBreakEvery = 4
+	MinDuration    = 1 * time.Minute
+	MaxDuration    = 4 * time.Hour
+	// MaxTextLength caps, in bytes, labels, tags, notes, reasons and task
+	// titles, all of which end up in the history.
+	MaxTextLength = 1024
+
+	MinTickInterval = 100 * time.Millisecond
 )
 
 type Server struct {
 	pb.UnimplementedTomatoServiceServer
 
+	// mut guards every field below. RPCs, and the timer firing, take it for
+	// the whole of any change so that each sees a consistent timer.
 	mut    sync.Mutex
+	clock  clock.Clock
 	ends   time.Time
-	tomato *time.Timer
+	tomato clock.Timer
+
+	// phase is the phase of the currently running timer, or PHASE_IDLE.
+	phase pb.Phase
+	// completed counts the tomatoes completed since the last long break.
+	completed int
+	// started and planned describe the current session so that it can be
+	// recorded to history once finished.
+	started time.Time
+	planned time.Duration
+	// paused is set while the timer is paused, left holding how long was
+	// remaining on the clock when it was.
+	paused   bool
+	pausedAt time.Time
+	left     time.Duration
+	// label and tags describe what the current tomato is being spent on,
+	// and task which task it counts against.
+	label string
+	tags  []string
+	task  uint32
+	// interruptions are those recorded against the current tomato.
+	interruptions []*pb.Interruption
+	// generation is bumped every time a timer is armed so that a timer
+	// firing after being superseded can be ignored.
+	generation uint64
+	// closed is set once Close has been called, after which the state file
+	// is left alone for the next server to restore.
+	closed bool
+
+	history   *History
+	stateFile *StateFile
+	tasks     *Tasks
+
+	notifier    notify.Notifier
+	notifyTitle string
+	notifyBody  string
+	// notifying tracks notifications still being sent, which Close waits
+	// for so that the notifier can then be closed.
+	notifying sync.WaitGroup
+
+	hooks     *hooks.Hooks
+	hooksDone chan struct{}
+	unhook    func()
+
+	events broker
+	// announced is the phase subscribers were last told about.
+	announced pb.Phase
+}
+
+type Option func(*Server)
+
+// WithHistory records every finished session to h.
+func WithHistory(h *History) Option {
+	return func(s *Server) {
+		s.history = h
+	}
 }
 
-func New() *Server {
-	return &Server{}
+// WithClock tells the time with c rather than the system clock.
+func WithClock(c clock.Clock) Option {
+	return func(s *Server) {
+		s.clock = c
+	}
+}
+
+// WithTasks keeps a todo list in t, counting completed tomatoes against the
+// task they were started for.
+func WithTasks(t *Tasks) Option {
+	return func(s *Server) {
+		s.tasks = t
+	}
+}
+
+// WithStateFile persists the timer to f, restoring it when the server is
+// created.
+func WithStateFile(f *StateFile) Option {
+	return func(s *Server) {
+		s.stateFile = f
+	}
+}
+
+// WithNotifier notifies the user through n whenever a tomato or break is
+// completed. Occurrences of {phase} and {next} in the title and body are
+// replaced with the phase which completed and the phase which follows it.
+func WithNotifier(n notify.Notifier, title, body string) Option {
+	return func(s *Server) {
+		s.notifier = n
+		s.notifyTitle = title
+		s.notifyBody = body
+	}
+}
+
+// WithHooks runs h in response to timer events.
+func WithHooks(h *hooks.Hooks) Option {
+	return func(s *Server) {
+		s.hooks = h
+	}
+}
+
+func New(opts ...Option) *Server {
+	s := &Server{clock: clock.Real}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.tasks != nil {
+		s.tasks.setClock(s.clock)
+	}
+
+	if s.hooks != nil {
+		events, unsubscribe := s.events.subscribe()
+		s.unhook = unsubscribe
+		s.hooksDone = make(chan struct{})
+
+		go func() {
+			defer close(s.hooksDone)
+
+			s.hooks.Run(events)
+		}()
+	}
+
+	s.mut.Lock()
+	s.restore()
+	s.mut.Unlock()
+
+	return s
 }
 
-func (s *Server) stop() time.Duration {
+// Close shuts down the timer and waits for any notifications still being sent
+// and hooks still running to exit. When the timer is persisted it is left to
+// be restored by the next server, otherwise it is stopped and recorded as
+// abandoned. Closing a server again does nothing.
+func (s *Server) Close() {
+	s.mut.Lock()
+	if s.closed {
+		s.mut.Unlock()
+		return
+	}
+
+	if s.stateFile != nil && s.tomato != nil {
+		s.generation++
+		s.tomato.Stop()
+		s.persist()
+	} else {
+		s.stop(pb.Outcome_OUTCOME_ABANDONED, "")
+		s.announce()
+	}
+	s.closed = true
+	s.mut.Unlock()
+
+	s.notifying.Wait()
+
+	if s.hooks != nil {
+		s.unhook()
+		<-s.hooksDone
+	}
+}
+
+// stop ends the current session with the given outcome, recording it along
+// with reason. A break stopped early is recorded as skipped.
+func (s *Server) stop(outcome pb.Outcome, reason string) time.Duration {
+	return s.finish(s.clock.Now(), outcome, reason)
+}
+
+// finish ends the current session at the given time, as stop.
+func (s *Server) finish(at time.Time, outcome pb.Outcome, reason string) time.Duration {
 	if s.tomato == nil {
 		return time.Duration(0)
 	}
 
-	remaining := s.remaining()
+	if outcome == pb.Outcome_OUTCOME_STOPPED && s.phase != pb.Phase_PHASE_WORK {
+		outcome = pb.Outcome_OUTCOME_SKIPPED
+	}
+
+	remaining := s.remainingAt(at)
+	s.record(outcome, reason, remaining, at)
+
+	if outcome == pb.Outcome_OUTCOME_COMPLETED && s.phase == pb.Phase_PHASE_WORK && s.task != 0 {
+		s.count()
+	}
+
+	event := s.eventAt(pb.EventType_EVENT_STOPPED, at)
+	if outcome == pb.Outcome_OUTCOME_COMPLETED {
+		event.Type = pb.EventType_EVENT_COMPLETED
+	}
+
+	event.State = pb.State_STATE_STOPPED
+	event.EndsAt = nil
+	event.Outcome = outcome
+	event.Reason = reason
+	s.events.publish(event)
+
+	if s.phase == pb.Phase_PHASE_LONG_BREAK {
+		s.completed = 0
+	}
 
+	s.tomato.Stop()
 	s.tomato = nil
-	s.ends = time.Now()
+	s.ends = at
+	s.phase = pb.Phase_PHASE_IDLE
+	s.paused = false
+	s.left = 0
+	s.label = ""
+	s.tags = nil
+	s.task = 0
+	s.interruptions = nil
+	s.persist()
 
 	return remaining
 }
 
-func (s *Server) start() (time.Time, error) {
-	if s.tomato != nil {
-		return time.Now(), fmt.Errorf("tomato is still runnning")
+func (s *Server) start(d time.Duration, label string, tags []string, task uint32) (time.Time, error) {
+	if s.phase == pb.Phase_PHASE_WORK {
+		return s.clock.Now(), status.Error(codes.FailedPrecondition, "tomato is still runnning")
+	}
+
+	// Starting a tomato cuts any break short.
+	s.stop(pb.Outcome_OUTCOME_SKIPPED, "")
+
+	s.label = label
+	s.tags = tags
+	s.task = task
+
+	return s.begin(s.clock.Now(), pb.Phase_PHASE_WORK, d), nil
+}
+
+// begin starts a new session for the given phase at the given time.
+func (s *Server) begin(at time.Time, phase pb.Phase, d time.Duration) time.Time {
+	s.phase = phase
+	s.started = at
+	s.planned = d
+	s.announce()
+
+	ends := s.run(at.Add(d))
+	s.events.publish(s.eventAt(pb.EventType_EVENT_STARTED, at))
+
+	return ends
+}
+
+// run arms the timer for the current phase to end at ends, calling expire
+// once it fires.
+func (s *Server) run(ends time.Time) time.Time {
+	s.generation++
+	generation := s.generation
+
+	s.paused = false
+	s.left = 0
+	s.tomato = s.clock.AfterFunc(ends.Sub(s.clock.Now()), func() { s.expire(generation) })
+	s.ends = ends
+	s.persist()
+
+	return s.ends
+}
+
+// expire is called when a timer runs out, completing the current phase.
+func (s *Server) expire(generation uint64) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if generation != s.generation || s.tomato == nil {
+		return
+	}
+
+	s.complete()
+}
+
+// complete finishes the current phase at the time it was due to end, moving
+// on to the next phase of the cycle from then. A completed tomato is
+// followed by a break, and a completed break leaves the server idle until
+// the next tomato is started.
+func (s *Server) complete() {
+	at := s.ends
+	finished := s.phase
+	s.finish(at, pb.Outcome_OUTCOME_COMPLETED, "")
+
+	if finished != pb.Phase_PHASE_WORK {
+		s.announce()
+		s.notify(finished, pb.Phase_PHASE_WORK)
+		return
+	}
+
+	s.completed++
+	if s.completed >= LongBreakEvery {
+		s.begin(at, pb.Phase_PHASE_LONG_BREAK, LongBreak)
+	} else {
+		s.begin(at, pb.Phase_PHASE_SHORT_BREAK, ShortBreak)
+	}
+
+	s.notify(finished, s.phase)
+}
+
+// notify lets the user know that the finished phase has completed, in the
+// background so as not to hold up the server.
+func (s *Server) notify(finished, next pb.Phase) {
+	if s.notifier == nil {
+		return
+	}
+
+	replacer := strings.NewReplacer("{phase}", phaseName(finished), "{next}", phaseName(next))
+	title := replacer.Replace(s.notifyTitle)
+	body := replacer.Replace(s.notifyBody)
+
+	s.notifying.Add(1)
+	go func() {
+		defer s.notifying.Done()
+
+		if err := s.notifier.Notify(title, body); err != nil {
+			log.Printf("error notifying: %v", err)
+		}
+	}()
+}
+
+func phaseName(phase pb.Phase) string {
+	switch phase {
+	case pb.Phase_PHASE_WORK:
+		return "tomato"
+	case pb.Phase_PHASE_SHORT_BREAK:
+		return "short break"
+	case pb.Phase_PHASE_LONG_BREAK:
+		return "long break"
+	default:
+		return "rest"
+	}
+}
+
+// count records the completed tomato against its task.
+func (s *Server) count() {
+	if s.tasks == nil {
+		return
+	}
+
+	if _, err := s.tasks.Count(s.task); err != nil {
+		log.Printf("error counting tomato against task %d: %v", s.task, err)
+	}
+}
+
+// record appends the current session to history, if enabled.
+func (s *Server) record(outcome pb.Outcome, reason string, remaining time.Duration, ended time.Time) {
+	if s.history == nil {
+		return
+	}
+
+	actual := s.planned - remaining
+	if outcome == pb.Outcome_OUTCOME_COMPLETED || actual > s.planned {
+		actual = s.planned
+	}
+
+	if actual < 0 {
+		actual = 0
+	}
+
+	session := &pb.Session{
+		Phase:         s.phase,
+		Outcome:       outcome,
+		StartedAt:     timestamppb.New(s.started),
+		EndedAt:       timestamppb.New(ended),
+		Planned:       durationpb.New(s.planned),
+		Actual:        durationpb.New(actual),
+		Label:         s.label,
+		Tags:          s.tags,
+		Task:          s.task,
+		Interruptions: s.interruptions,
+		Reason:        reason,
+	}
+
+	if err := s.history.Append(session); err != nil {
+		log.Printf("error recording session: %v", err)
+	}
+}
+
+func (s *Server) pause() (time.Duration, error) {
+	if s.tomato == nil {
+		return time.Duration(0), status.Error(codes.FailedPrecondition, "tomato is not running")
+	}
+
+	if s.paused {
+		return time.Duration(0), status.Error(codes.FailedPrecondition, "tomato is already paused")
+	}
+
+	s.left = s.remaining()
+	s.paused = true
+	s.pausedAt = s.clock.Now()
+	s.generation++
+	s.tomato.Stop()
+	s.persist()
+	s.emit(pb.EventType_EVENT_PAUSED)
+
+	return s.left, nil
+}
+
+// interrupt records an interruption against the current tomato.
+func (s *Server) interrupt(external bool, note string) (*pb.Interruption, error) {
+	if s.phase != pb.Phase_PHASE_WORK {
+		return nil, status.Error(codes.FailedPrecondition, "tomato is not running")
+	}
+
+	interruption := &pb.Interruption{
+		Kind: pb.InterruptionKind_INTERRUPTION_INTERNAL,
+		At:   timestamppb.New(s.clock.Now()),
+		Note: note,
+	}
+
+	if external {
+		interruption.Kind = pb.InterruptionKind_INTERRUPTION_EXTERNAL
+	}
+
+	s.interruptions = append(s.interruptions, interruption)
+	s.persist()
+
+	return interruption, nil
+}
+
+func (s *Server) resume() (time.Time, error) {
+	if !s.paused {
+		return s.clock.Now(), status.Error(codes.FailedPrecondition, "tomato is not paused")
+	}
+
+	ends := s.run(s.clock.Now().Add(s.left))
+	s.emit(pb.EventType_EVENT_RESUMED)
+
+	return ends, nil
+}
+
+// event returns a snapshot of the timer as an event of the given type.
+func (s *Server) event(t pb.EventType) *pb.Event {
+	return s.eventAt(t, s.clock.Now())
+}
+
+// eventAt returns a snapshot of the timer as of the given time.
+func (s *Server) eventAt(t pb.EventType, at time.Time) *pb.Event {
+	event := &pb.Event{
+		Type:      t,
+		At:        timestamppb.New(at),
+		Phase:     s.phase,
+		State:     s.state(),
+		Remaining: durationpb.New(s.remainingAt(at)),
+	}
+
+	if event.State == pb.State_STATE_RUNNING {
+		event.EndsAt = timestamppb.New(s.ends)
+	}
+
+	return event
+}
+
+func (s *Server) emit(t pb.EventType) {
+	s.events.publish(s.event(t))
+}
+
+// announce tells subscribers about the current phase, if it has changed since
+// they were last told.
+func (s *Server) announce() {
+	if s.phase == s.announced {
+		return
 	}
 
-	s.tomato = time.AfterFunc(Duration, func() { s.stop() })
-	s.ends = time.Now().Add(Duration)
+	s.announced = s.phase
+	s.emit(pb.EventType_EVENT_PHASE_CHANGED)
+}
 
-	return s.ends, nil
+func (s *Server) state() pb.State {
+	switch {
+	case s.tomato == nil:
+		return pb.State_STATE_STOPPED
+	case s.paused:
+		return pb.State_STATE_PAUSED
+	default:
+		return pb.State_STATE_RUNNING
+	}
 }
 
 func (s *Server) remaining() time.Duration {
+	return s.remainingAt(s.clock.Now())
+}
+
+func (s *Server) remainingAt(at time.Time) time.Duration {
 	if s.tomato == nil {
 		return time.Duration(0)
 	}
 
-	return time.Until(s.ends)
+	if s.paused {
+		return s.left
+	}
+
+	return s.ends.Sub(at)
+}
+
+// duration returns the length of tomato requested, falling back to Duration
+// when none was given.
+func duration(req *pb.StartRequest) (time.Duration, error) {
+	if req.GetDuration() == nil {
+		return Duration, nil
+	}
+
+	if err := req.GetDuration().CheckValid(); err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid duration: %v", err)
+	}
+
+	d := req.GetDuration().AsDuration()
+	if d < MinDuration || d > MaxDuration {
+		return 0, status.Errorf(codes.InvalidArgument, "duration must be between %v and %v, got %v", MinDuration, MaxDuration, d)
+	}
+
+	return d, nil
 }
-func (s *Server) Start(ctx context.Context, _ *emptypb.Empty) (*timestamppb.Timestamp, error) {
+
+// tags returns the tags requested, trimmed and without duplicates.
+func tags(req *pb.StartRequest) ([]string, error) {
+	seen := map[string]bool{}
+	tags := []string{}
+
+	for _, tag := range req.GetTags() {
+		tag, err := text("tag", tag)
+		if err != nil {
+			return nil, err
+		}
+
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// text returns value trimmed, refusing it if longer than MaxTextLength.
+func text(name, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if len(value) > MaxTextLength {
+		return "", status.Errorf(codes.InvalidArgument, "%v must be at most %d bytes, got %d", name, MaxTextLength, len(value))
+	}
+
+	return value, nil
+}
+
+func (s *Server) Start(ctx context.Context, req *pb.StartRequest) (*timestamppb.Timestamp, error) {
+	d, err := duration(req)
+	if err != nil {
+		return nil, err
+	}
+
+	label, err := text("label", req.GetLabel())
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := tags(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetTask() != 0 {
+		task, err := s.startable(req.GetTask())
+		if err != nil {
+			return nil, err
+		}
+
+		if label == "" {
+			label = task.GetTitle()
+		}
+	}
+
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
-	ends, err := s.start()
+	ends, err := s.start(d, label, tags, req.GetTask())
 
 	return timestamppb.New(ends), err
 
 }
 
-func (s *Server) Stop(ctx context.Context, _ *emptypb.Empty) (*durationpb.Duration, error) {
+func (s *Server) Stop(ctx context.Context, req *pb.StopRequest) (*durationpb.Duration, error) {
+	reason, err := text("reason", req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
-	return durationpb.New(s.stop()), nil
+	left := s.stop(pb.Outcome_OUTCOME_STOPPED, reason)
+	s.announce()
+
+	return durationpb.New(left), nil
 }
 
-func (s *Server) Running(ctx context.Context, _ *emptypb.Empty) (*wrapperspb.BoolValue, error) {
-	running := s.tomato != nil
+func (s *Server) Running(ctx context.Context, _ *emptypb.Empty) (*pb.RunningResponse, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
 
-	return wrapperspb.Bool(running), nil
+	return &pb.RunningResponse{State: s.state()}, nil
 }
 
 func (s *Server) Remaining(ctx context.Context, _ *emptypb.Empty) (*durationpb.Duration, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
 	return durationpb.New(s.remaining()), nil
 }
+
+func (s *Server) Phase(ctx context.Context, _ *emptypb.Empty) (*pb.PhaseResponse, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	return &pb.PhaseResponse{
+		Phase:          s.phase,
+		Completed:      uint32(s.completed),
+		LongBreakEvery: uint32(LongBreakEvery),
+	}, nil
+}
+
+func (s *Server) Status(ctx context.Context, _ *emptypb.Empty) (*pb.TomatoStatus, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	status := &pb.TomatoStatus{
+		State:          s.state(),
+		Phase:          s.phase,
+		Remaining:      durationpb.New(s.remaining()),
+		Label:          s.label,
+		Tags:           s.tags,
+		Task:           s.task,
+		Interruptions:  uint32(len(s.interruptions)),
+		Cycle:          uint32(s.completed),
+		LongBreakEvery: uint32(LongBreakEvery),
+	}
+
+	switch status.State {
+	case pb.State_STATE_RUNNING:
+		status.StartedAt = timestamppb.New(s.started)
+		status.EndsAt = timestamppb.New(s.ends)
+	case pb.State_STATE_PAUSED:
+		status.StartedAt = timestamppb.New(s.started)
+		status.PausedAt = timestamppb.New(s.pausedAt)
+	}
+
+	return status, nil
+}
+
+func (s *Server) Pause(ctx context.Context, _ *emptypb.Empty) (*durationpb.Duration, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	left, err := s.pause()
+
+	return durationpb.New(left), err
+}
+
+func (s *Server) Resume(ctx context.Context, _ *emptypb.Empty) (*timestamppb.Timestamp, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	ends, err := s.resume()
+
+	return timestamppb.New(ends), err
+}
+
+func (s *Server) Interrupt(ctx context.Context, req *pb.InterruptRequest) (*pb.Interruption, error) {
+	note, err := text("note", req.GetNote())
+	if err != nil {
+		return nil, err
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	return s.interrupt(req.GetExternal(), note)
+}
+
+func (s *Server) History(ctx context.Context, req *pb.HistoryRequest) (*pb.HistoryResponse, error) {
+	if s.history == nil {
+		return nil, status.Error(codes.FailedPrecondition, "history is not enabled")
+	}
+
+	var since, until time.Time
+	if req.GetSince() != nil {
+		since = req.GetSince().AsTime()
+	}
+
+	if req.GetUntil() != nil {
+		until = req.GetUntil().AsTime()
+	}
+
+	sessions, err := s.history.Sessions(since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.HistoryResponse{Sessions: sessions}, nil
+}
+
+func (s *Server) Stats(ctx context.Context, req *pb.StatsRequest) (*pb.StatsResponse, error) {
+	if s.history == nil {
+		return nil, status.Error(codes.FailedPrecondition, "history is not enabled")
+	}
+
+	var since, until time.Time
+	if req.GetSince() != nil {
+		since = req.GetSince().AsTime()
+	}
+
+	if req.GetUntil() != nil {
+		until = req.GetUntil().AsTime()
+	}
+
+	// Every session up to until is needed to count streaks, not just those
+	// since since.
+	sessions, err := s.history.Sessions(time.Time{}, until)
+	if err != nil {
+		return nil, err
+	}
+
+	return Stats(sessions, since, until, s.clock.Now()), nil
+}
+
+func (s *Server) Watch(req *pb.WatchRequest, stream pb.TomatoService_WatchServer) error {
+	interval := time.Second
+	if req.GetTickInterval() != nil {
+		interval = req.GetTickInterval().AsDuration()
+	}
+
+	if interval < MinTickInterval {
+		return status.Errorf(codes.InvalidArgument, "tick interval must be at least %v, got %v", MinTickInterval, interval)
+	}
+
+	events, unsubscribe := s.events.subscribe()
+	defer unsubscribe()
+
+	ticker := s.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-ticker.C():
+			s.mut.Lock()
+			event := s.event(pb.EventType_EVENT_TICK)
+			s.mut.Unlock()
+
+			if event.GetState() != pb.State_STATE_RUNNING {
+				continue
+			}
+
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// startable returns the task with the given id, if tomatoes can be started
+// against it.
+func (s *Server) startable(id uint32) (*pb.Task, error) {
+	if s.tasks == nil {
+		return nil, status.Error(codes.FailedPrecondition, "tasks are not enabled")
+	}
+
+	task, err := s.tasks.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.GetDone() {
+		return nil, status.Errorf(codes.FailedPrecondition, "task %d is already done", id)
+	}
+
+	return task, nil
+}
+
+func (s *Server) AddTask(ctx context.Context, req *pb.AddTaskRequest) (*pb.Task, error) {
+	if s.tasks == nil {
+		return nil, status.Error(codes.FailedPrecondition, "tasks are not enabled")
+	}
+
+	title, err := text("title", req.GetTitle())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.tasks.Add(title, req.GetEstimate())
+}
+
+func (s *Server) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	if s.tasks == nil {
+		return nil, status.Error(codes.FailedPrecondition, "tasks are not enabled")
+	}
+
+	tasks, err := s.tasks.List(req.GetAll())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListTasksResponse{Tasks: tasks}, nil
+}
+
+func (s *Server) CompleteTask(ctx context.Context, req *pb.CompleteTaskRequest) (*pb.Task, error) {
+	if s.tasks == nil {
+		return nil, status.Error(codes.FailedPrecondition, "tasks are not enabled")
+	}
+
+	return s.tasks.Complete(req.GetId())
+}
+
+func (s *Server) EstimateTask(ctx context.Context, req *pb.EstimateTaskRequest) (*pb.Task, error) {
+	if s.tasks == nil {
+		return nil, status.Error(codes.FailedPrecondition, "tasks are not enabled")
+	}
+
+	return s.tasks.Estimate(req.GetId(), req.GetEstimate())
+}
@@ -0,0 +1,894 @@
+package server_test
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/CGA1123/tomato/clock"
+	"github.com/CGA1123/tomato/pb"
+	"github.com/CGA1123/tomato/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// shorten makes every phase of the cycle last d for the duration of the test.
+func shorten(t *testing.T, d time.Duration) {
+	t.Helper()
+
+	duration, short, long, every, min := server.Duration, server.ShortBreak, server.LongBreak, server.LongBreakEvery, server.MinDuration
+	t.Cleanup(func() {
+		server.Duration, server.ShortBreak, server.LongBreak, server.LongBreakEvery, server.MinDuration = duration, short, long, every, min
+	})
+
+	server.Duration, server.ShortBreak, server.LongBreak, server.LongBreakEvery, server.MinDuration = d, d, d, 2, time.Millisecond
+}
+
+// newServer returns a server keeping its files in dir, or a temporary
+// directory if empty, closed once the test finishes.
+func newServer(t *testing.T, dir string, opts ...server.Option) (*server.Server, *server.History) {
+	t.Helper()
+
+	if dir == "" {
+		dir = t.TempDir()
+	}
+
+	history := server.NewHistory(filepath.Join(dir, "history"))
+
+	s := server.New(append([]server.Option{
+		server.WithHistory(history),
+		server.WithStateFile(server.NewStateFile(filepath.Join(dir, "state.json"))),
+		server.WithTasks(server.NewTasks(filepath.Join(dir, "tasks.json"))),
+	}, opts...)...)
+	t.Cleanup(s.Close)
+
+	return s, history
+}
+
+// newFakeServer returns a server, as newServer, whose clock only moves when
+// advanced.
+func newFakeServer(t *testing.T, dir string) (*server.Server, *server.History, *clock.Fake) {
+	t.Helper()
+
+	fake := clock.NewFake(time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC))
+	s, history := newServer(t, dir, server.WithClock(fake))
+
+	return s, history, fake
+}
+
+func status(t *testing.T, s *server.Server) *pb.TomatoStatus {
+	t.Helper()
+
+	status, err := s.Status(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("error getting status: %v", err)
+	}
+
+	return status
+}
+
+func remaining(t *testing.T, s *server.Server) time.Duration {
+	t.Helper()
+
+	left, err := s.Remaining(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("error getting remaining: %v", err)
+	}
+
+	return left.AsDuration()
+}
+
+func sessions(t *testing.T, history *server.History) []*pb.Session {
+	t.Helper()
+
+	sessions, err := history.Sessions(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("error reading history: %v", err)
+	}
+
+	return sessions
+}
+
+func expectPhase(t *testing.T, s *server.Server, state pb.State, phase pb.Phase) {
+	t.Helper()
+
+	got := status(t, s)
+	if got.GetState() != state || got.GetPhase() != phase {
+		t.Fatalf("expected %v %v, got %v %v", state, phase, got.GetState(), got.GetPhase())
+	}
+}
+
+// watchStream collects the events sent by Watch.
+type watchStream struct {
+	grpc.ServerStream
+
+	ctx    context.Context
+	mut    sync.Mutex
+	events []*pb.Event
+}
+
+func (w *watchStream) Context() context.Context {
+	return w.ctx
+}
+
+func (w *watchStream) Send(event *pb.Event) error {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	w.events = append(w.events, event)
+
+	return nil
+}
+
+func TestConcurrentCalls(t *testing.T) {
+	shorten(t, 5*time.Millisecond)
+	s, _ := newServer(t, "")
+	ctx := context.Background()
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	stream := &watchStream{ctx: watchCtx}
+	watched := make(chan error, 1)
+	go func() {
+		watched <- s.Watch(&pb.WatchRequest{TickInterval: durationpb.New(server.MinTickInterval)}, stream)
+	}()
+
+	calls := []func(){
+		func() { s.Start(ctx, &pb.StartRequest{Label: "stress", Tags: []string{"test"}}) },
+		func() { s.Start(ctx, &pb.StartRequest{Duration: durationpb.New(time.Millisecond)}) },
+		func() { s.Stop(ctx, &pb.StopRequest{Reason: "stress"}) },
+		func() { s.Pause(ctx, &emptypb.Empty{}) },
+		func() { s.Resume(ctx, &emptypb.Empty{}) },
+		func() { s.Running(ctx, &emptypb.Empty{}) },
+		func() { s.Remaining(ctx, &emptypb.Empty{}) },
+		func() { s.Phase(ctx, &emptypb.Empty{}) },
+		func() { s.Status(ctx, &emptypb.Empty{}) },
+		func() { s.Interrupt(ctx, &pb.InterruptRequest{Note: "stress"}) },
+		func() { s.History(ctx, &pb.HistoryRequest{}) },
+		func() { s.Stats(ctx, &pb.StatsRequest{}) },
+	}
+
+	var wg sync.WaitGroup
+	deadline := time.Now().Add(500 * time.Millisecond)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for n := i; time.Now().Before(deadline); n++ {
+				calls[n%len(calls)]()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	cancel()
+
+	if err := <-watched; err != nil {
+		t.Fatalf("error watching: %v", err)
+	}
+
+	stream.mut.Lock()
+	defer stream.mut.Unlock()
+
+	if len(stream.events) == 0 {
+		t.Fatalf("expected events to be sent to watchers")
+	}
+}
+
+func TestStart(t *testing.T) {
+	s, _, fake := newFakeServer(t, "")
+	ctx := context.Background()
+
+	ends, err := s.Start(ctx, &pb.StartRequest{Label: "write tests"})
+	if err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	if want := fake.Now().Add(server.Duration); !ends.AsTime().Equal(want) {
+		t.Fatalf("expected tomato to end at %v, got %v", want, ends.AsTime())
+	}
+
+	expectPhase(t, s, pb.State_STATE_RUNNING, pb.Phase_PHASE_WORK)
+
+	if got := status(t, s).GetLabel(); got != "write tests" {
+		t.Fatalf("expected label %q, got %q", "write tests", got)
+	}
+
+	if _, err := s.Start(ctx, &pb.StartRequest{}); err == nil {
+		t.Fatalf("expected starting a second tomato to fail")
+	}
+
+	for _, d := range []time.Duration{server.MinDuration - time.Second, server.MaxDuration + time.Second} {
+		if _, err := s.Start(ctx, &pb.StartRequest{Duration: durationpb.New(d)}); err == nil {
+			t.Errorf("expected starting a %v tomato to fail", d)
+		}
+	}
+}
+
+func TestTextLength(t *testing.T) {
+	s, _, _ := newFakeServer(t, "")
+	ctx := context.Background()
+	long := strings.Repeat("a", server.MaxTextLength+1)
+
+	if _, err := s.Start(ctx, &pb.StartRequest{Label: long}); err == nil {
+		t.Fatalf("expected starting with a long label to fail")
+	}
+
+	if _, err := s.Start(ctx, &pb.StartRequest{Tags: []string{long}}); err == nil {
+		t.Fatalf("expected starting with a long tag to fail")
+	}
+
+	if _, err := s.AddTask(ctx, &pb.AddTaskRequest{Title: long}); err == nil {
+		t.Fatalf("expected adding a task with a long title to fail")
+	}
+
+	if _, err := s.Start(ctx, &pb.StartRequest{Label: long[1:]}); err != nil {
+		t.Fatalf("error starting with a label of the maximum length: %v", err)
+	}
+
+	if _, err := s.Interrupt(ctx, &pb.InterruptRequest{Note: long}); err == nil {
+		t.Fatalf("expected interrupting with a long note to fail")
+	}
+
+	if _, err := s.Stop(ctx, &pb.StopRequest{Reason: long}); err == nil {
+		t.Fatalf("expected stopping with a long reason to fail")
+	}
+
+	expectPhase(t, s, pb.State_STATE_RUNNING, pb.Phase_PHASE_WORK)
+}
+
+func TestRemaining(t *testing.T) {
+	s, _, fake := newFakeServer(t, "")
+	ctx := context.Background()
+
+	if got := remaining(t, s); got != 0 {
+		t.Fatalf("expected nothing remaining before starting, got %v", got)
+	}
+
+	if _, err := s.Start(ctx, &pb.StartRequest{Duration: durationpb.New(10 * time.Minute)}); err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	fake.Advance(4 * time.Minute)
+	if got := remaining(t, s); got != 6*time.Minute {
+		t.Fatalf("expected 6m remaining, got %v", got)
+	}
+
+	if _, err := s.Pause(ctx, &emptypb.Empty{}); err != nil {
+		t.Fatalf("error pausing: %v", err)
+	}
+
+	fake.Advance(time.Hour)
+	expectPhase(t, s, pb.State_STATE_PAUSED, pb.Phase_PHASE_WORK)
+	if got := remaining(t, s); got != 6*time.Minute {
+		t.Fatalf("expected 6m remaining while paused, got %v", got)
+	}
+
+	ends, err := s.Resume(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("error resuming: %v", err)
+	}
+
+	if want := fake.Now().Add(6 * time.Minute); !ends.AsTime().Equal(want) {
+		t.Fatalf("expected tomato to end at %v once resumed, got %v", want, ends.AsTime())
+	}
+
+	fake.Advance(time.Minute)
+	if got := remaining(t, s); got != 5*time.Minute {
+		t.Fatalf("expected 5m remaining, got %v", got)
+	}
+}
+
+func TestStop(t *testing.T) {
+	s, history, fake := newFakeServer(t, "")
+	ctx := context.Background()
+
+	if _, err := s.Start(ctx, &pb.StartRequest{}); err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	fake.Advance(10 * time.Minute)
+
+	left, err := s.Stop(ctx, &pb.StopRequest{Reason: "meeting"})
+	if err != nil {
+		t.Fatalf("error stopping: %v", err)
+	}
+
+	if want := server.Duration - 10*time.Minute; left.AsDuration() != want {
+		t.Fatalf("expected %v left when stopped, got %v", want, left.AsDuration())
+	}
+
+	expectPhase(t, s, pb.State_STATE_STOPPED, pb.Phase_PHASE_IDLE)
+
+	if fake.Pending() != 0 {
+		t.Fatalf("expected the timer to be cancelled, %d pending", fake.Pending())
+	}
+
+	// The stopped tomato never completes.
+	fake.Advance(time.Hour)
+	expectPhase(t, s, pb.State_STATE_STOPPED, pb.Phase_PHASE_IDLE)
+
+	got := sessions(t, history)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(got))
+	}
+
+	session := got[0]
+	if session.GetOutcome() != pb.Outcome_OUTCOME_STOPPED || session.GetReason() != "meeting" {
+		t.Fatalf("expected a tomato stopped for a meeting, got %v %q", session.GetOutcome(), session.GetReason())
+	}
+
+	if session.GetActual().AsDuration() != 10*time.Minute {
+		t.Fatalf("expected 10m to have been spent, got %v", session.GetActual().AsDuration())
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	s, history, fake := newFakeServer(t, "")
+	ctx := context.Background()
+
+	if _, err := s.Start(ctx, &pb.StartRequest{}); err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	fake.Advance(server.Duration - time.Second)
+	expectPhase(t, s, pb.State_STATE_RUNNING, pb.Phase_PHASE_WORK)
+
+	fake.Advance(time.Second)
+	expectPhase(t, s, pb.State_STATE_RUNNING, pb.Phase_PHASE_SHORT_BREAK)
+
+	if got := status(t, s).GetCycle(); got != 1 {
+		t.Fatalf("expected 1 tomato completed this cycle, got %d", got)
+	}
+
+	if got := remaining(t, s); got != server.ShortBreak {
+		t.Fatalf("expected the short break to have %v remaining, got %v", server.ShortBreak, got)
+	}
+
+	fake.Advance(server.ShortBreak)
+	expectPhase(t, s, pb.State_STATE_STOPPED, pb.Phase_PHASE_IDLE)
+
+	got := sessions(t, history)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(got))
+	}
+
+	for i, phase := range []pb.Phase{pb.Phase_PHASE_WORK, pb.Phase_PHASE_SHORT_BREAK} {
+		if got[i].GetPhase() != phase || got[i].GetOutcome() != pb.Outcome_OUTCOME_COMPLETED {
+			t.Errorf("expected session %d to be a completed %v, got %v %v", i, phase, got[i].GetOutcome(), got[i].GetPhase())
+		}
+	}
+}
+
+func TestLongBreak(t *testing.T) {
+	s, _, fake := newFakeServer(t, "")
+	ctx := context.Background()
+
+	for i := 1; i < server.LongBreakEvery; i++ {
+		if _, err := s.Start(ctx, &pb.StartRequest{}); err != nil {
+			t.Fatalf("error starting tomato %d: %v", i, err)
+		}
+
+		fake.Advance(server.Duration)
+		expectPhase(t, s, pb.State_STATE_RUNNING, pb.Phase_PHASE_SHORT_BREAK)
+		fake.Advance(server.ShortBreak)
+	}
+
+	if _, err := s.Start(ctx, &pb.StartRequest{}); err != nil {
+		t.Fatalf("error starting last tomato: %v", err)
+	}
+
+	fake.Advance(server.Duration)
+	expectPhase(t, s, pb.State_STATE_RUNNING, pb.Phase_PHASE_LONG_BREAK)
+
+	fake.Advance(server.LongBreak)
+	expectPhase(t, s, pb.State_STATE_STOPPED, pb.Phase_PHASE_IDLE)
+
+	if got := status(t, s).GetCycle(); got != 0 {
+		t.Fatalf("expected the cycle to restart after a long break, got %d", got)
+	}
+}
+
+func TestSkipBreak(t *testing.T) {
+	s, history, fake := newFakeServer(t, "")
+	ctx := context.Background()
+
+	if _, err := s.Start(ctx, &pb.StartRequest{}); err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	fake.Advance(server.Duration)
+
+	if _, err := s.Start(ctx, &pb.StartRequest{}); err != nil {
+		t.Fatalf("error starting during a break: %v", err)
+	}
+
+	expectPhase(t, s, pb.State_STATE_RUNNING, pb.Phase_PHASE_WORK)
+
+	got := sessions(t, history)
+	if len(got) != 2 || got[1].GetOutcome() != pb.Outcome_OUTCOME_SKIPPED {
+		t.Fatalf("expected the break to have been skipped, got %v", got)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	dir := t.TempDir()
+	s, _, fake := newFakeServer(t, dir)
+	ctx := context.Background()
+
+	if _, err := s.Start(ctx, &pb.StartRequest{Label: "survive"}); err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	fake.Advance(10 * time.Minute)
+	s.Close()
+
+	restarted, history := newServer(t, dir, server.WithClock(fake))
+	expectPhase(t, restarted, pb.State_STATE_RUNNING, pb.Phase_PHASE_WORK)
+
+	if got := remaining(t, restarted); got != server.Duration-10*time.Minute {
+		t.Fatalf("expected %v remaining after restarting, got %v", server.Duration-10*time.Minute, got)
+	}
+
+	if got := status(t, restarted).GetLabel(); got != "survive" {
+		t.Fatalf("expected label to survive restarting, got %q", got)
+	}
+
+	restarted.Close()
+
+	// The tomato runs out while the server is down, so completes when it was
+	// due and the break starts from then.
+	began := fake.Now().Add(-10 * time.Minute)
+	ended := began.Add(server.Duration)
+	fake.Advance(server.Duration - 10*time.Minute + time.Minute)
+
+	again, _ := newServer(t, dir, server.WithClock(fake))
+	expectPhase(t, again, pb.State_STATE_RUNNING, pb.Phase_PHASE_SHORT_BREAK)
+
+	if got := remaining(t, again); got != server.ShortBreak-time.Minute {
+		t.Fatalf("expected the break to have started when the tomato ended, %v remaining, got %v", server.ShortBreak-time.Minute, got)
+	}
+
+	got := sessions(t, history)
+	if len(got) != 1 || got[0].GetOutcome() != pb.Outcome_OUTCOME_COMPLETED || !got[0].GetEndedAt().AsTime().Equal(ended) {
+		t.Fatalf("expected the tomato to have completed at %v, got %v", ended, got)
+	}
+
+	again.Close()
+
+	// The break runs out too while the server is down.
+	fake.Advance(time.Hour)
+	last, _ := newServer(t, dir, server.WithClock(fake))
+	expectPhase(t, last, pb.State_STATE_STOPPED, pb.Phase_PHASE_IDLE)
+
+	got = sessions(t, history)
+	if len(got) != 2 || got[1].GetOutcome() != pb.Outcome_OUTCOME_COMPLETED {
+		t.Fatalf("expected the break to have completed, got %v", got)
+	}
+
+	if start, end := got[1].GetStartedAt().AsTime(), got[1].GetEndedAt().AsTime(); !start.Equal(ended) || !end.Equal(ended.Add(server.ShortBreak)) {
+		t.Fatalf("expected the break to have run from %v to %v, got %v to %v", ended, ended.Add(server.ShortBreak), start, end)
+	}
+}
+
+func TestCloseTwice(t *testing.T) {
+	dir := t.TempDir()
+	s, _, fake := newFakeServer(t, dir)
+	ctx := context.Background()
+
+	if _, err := s.Start(ctx, &pb.StartRequest{}); err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	s.Close()
+
+	restarted, _ := newServer(t, dir, server.WithClock(fake))
+	if _, err := restarted.Stop(ctx, &pb.StopRequest{}); err != nil {
+		t.Fatalf("error stopping: %v", err)
+	}
+
+	// Neither closing the first server again, nor calling it once closed,
+	// overwrites the state left by the second.
+	s.Close()
+	if _, err := s.Pause(ctx, &emptypb.Empty{}); err != nil {
+		t.Fatalf("error pausing: %v", err)
+	}
+
+	again, _ := newServer(t, dir, server.WithClock(fake))
+	expectPhase(t, again, pb.State_STATE_STOPPED, pb.Phase_PHASE_IDLE)
+}
+
+func TestWatchTicks(t *testing.T) {
+	s, _, fake := newFakeServer(t, "")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := s.Start(ctx, &pb.StartRequest{}); err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	stream := &watchStream{ctx: ctx}
+	watched := make(chan error, 1)
+	go func() {
+		watched <- s.Watch(&pb.WatchRequest{TickInterval: durationpb.New(time.Minute)}, stream)
+	}()
+
+	// Wait for Watch to start its ticker alongside the tomato's timer.
+	for fake.Pending() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	fake.Advance(time.Minute)
+
+	var events []*pb.Event
+	for deadline := time.Now().Add(time.Second); len(events) == 0 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+
+		stream.mut.Lock()
+		events = append(events, stream.events...)
+		stream.mut.Unlock()
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected a single tick, got %v", events)
+	}
+
+	tick := events[0]
+	if tick.GetType() != pb.EventType_EVENT_TICK || !tick.GetAt().AsTime().Equal(fake.Now()) {
+		t.Fatalf("expected a tick at %v, got %v", fake.Now(), tick)
+	}
+
+	if want := server.Duration - time.Minute; tick.GetRemaining().AsDuration() != want {
+		t.Fatalf("expected %v remaining, got %v", want, tick.GetRemaining().AsDuration())
+	}
+
+	cancel()
+	if err := <-watched; err != nil {
+		t.Fatalf("error watching: %v", err)
+	}
+}
+
+func TestTaskTimestamps(t *testing.T) {
+	s, _, fake := newFakeServer(t, "")
+	ctx := context.Background()
+
+	task, err := s.AddTask(ctx, &pb.AddTaskRequest{Title: "write tests", Estimate: 2})
+	if err != nil {
+		t.Fatalf("error adding task: %v", err)
+	}
+
+	if created := task.GetCreatedAt().AsTime(); !created.Equal(fake.Now()) {
+		t.Fatalf("expected task to be created at %v, got %v", fake.Now(), created)
+	}
+
+	fake.Advance(time.Hour)
+
+	task, err = s.CompleteTask(ctx, &pb.CompleteTaskRequest{Id: task.GetId()})
+	if err != nil {
+		t.Fatalf("error completing task: %v", err)
+	}
+
+	if done := task.GetDoneAt().AsTime(); !done.Equal(fake.Now()) {
+		t.Fatalf("expected task to be done at %v, got %v", fake.Now(), done)
+	}
+}
+
+func TestTaskTomatoes(t *testing.T) {
+	s, history, fake := newFakeServer(t, "")
+	ctx := context.Background()
+
+	task, err := s.AddTask(ctx, &pb.AddTaskRequest{Title: "write tests", Estimate: 2})
+	if err != nil {
+		t.Fatalf("error adding task: %v", err)
+	}
+
+	// A completed tomato counts against its task, taking the task's title
+	// as its label.
+	if _, err := s.Start(ctx, &pb.StartRequest{Task: task.GetId()}); err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	if got := status(t, s).GetLabel(); got != "write tests" {
+		t.Fatalf("expected the task's title as the label, got %q", got)
+	}
+
+	fake.Advance(server.Duration)
+
+	// A stopped one doesn't.
+	if _, err := s.Start(ctx, &pb.StartRequest{Task: task.GetId()}); err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	fake.Advance(time.Minute)
+	if _, err := s.Stop(ctx, &pb.StopRequest{}); err != nil {
+		t.Fatalf("error stopping: %v", err)
+	}
+
+	tasks, err := s.ListTasks(ctx, &pb.ListTasksRequest{})
+	if err != nil {
+		t.Fatalf("error listing tasks: %v", err)
+	}
+
+	if len(tasks.GetTasks()) != 1 || tasks.GetTasks()[0].GetTomatoes() != 1 {
+		t.Fatalf("expected 1 tomato counted against the task, got %v", tasks.GetTasks())
+	}
+
+	var counted []uint32
+	for _, session := range sessions(t, history) {
+		if session.GetPhase() == pb.Phase_PHASE_WORK {
+			counted = append(counted, session.GetTask())
+		}
+	}
+
+	if len(counted) != 2 || counted[0] != task.GetId() || counted[1] != task.GetId() {
+		t.Fatalf("expected both tomatoes to be recorded against the task, got %v", counted)
+	}
+
+	// Tomatoes can't be started against a task once it is done.
+	if _, err := s.CompleteTask(ctx, &pb.CompleteTaskRequest{Id: task.GetId()}); err != nil {
+		t.Fatalf("error completing task: %v", err)
+	}
+
+	if _, err := s.Start(ctx, &pb.StartRequest{Task: task.GetId()}); err == nil {
+		t.Fatalf("expected starting a tomato against a done task to fail")
+	}
+}
+
+func TestEstimateTask(t *testing.T) {
+	s, _, _ := newFakeServer(t, "")
+	ctx := context.Background()
+
+	task, err := s.AddTask(ctx, &pb.AddTaskRequest{Title: "write tests", Estimate: 2})
+	if err != nil {
+		t.Fatalf("error adding task: %v", err)
+	}
+
+	task, err = s.EstimateTask(ctx, &pb.EstimateTaskRequest{Id: task.GetId(), Estimate: 4})
+	if err != nil {
+		t.Fatalf("error estimating task: %v", err)
+	}
+
+	if task.GetEstimate() != 4 {
+		t.Fatalf("expected the task to be estimated at 4 tomatoes, got %d", task.GetEstimate())
+	}
+
+	tasks, err := s.ListTasks(ctx, &pb.ListTasksRequest{})
+	if err != nil {
+		t.Fatalf("error listing tasks: %v", err)
+	}
+
+	if len(tasks.GetTasks()) != 1 || tasks.GetTasks()[0].GetEstimate() != 4 {
+		t.Fatalf("expected the new estimate to be saved, got %v", tasks.GetTasks())
+	}
+
+	if _, err := s.EstimateTask(ctx, &pb.EstimateTaskRequest{Id: task.GetId() + 1, Estimate: 1}); err == nil {
+		t.Fatalf("expected estimating an unknown task to fail")
+	}
+}
+
+func TestInterruptions(t *testing.T) {
+	s, history, fake := newFakeServer(t, "")
+	ctx := context.Background()
+
+	if _, err := s.Interrupt(ctx, &pb.InterruptRequest{Note: "idle"}); err == nil {
+		t.Fatalf("expected interrupting without a tomato running to fail")
+	}
+
+	if _, err := s.Start(ctx, &pb.StartRequest{}); err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	interruptions := []*pb.InterruptRequest{
+		{Note: "checked email"},
+		{External: true, Note: "phone call"},
+		{External: true, Note: "fire alarm"},
+	}
+
+	for _, req := range interruptions {
+		fake.Advance(time.Minute)
+		if _, err := s.Interrupt(ctx, req); err != nil {
+			t.Fatalf("error interrupting: %v", err)
+		}
+	}
+
+	fake.Advance(server.Duration + server.ShortBreak)
+
+	got := sessions(t, history)
+	if len(got) != 2 || got[0].GetOutcome() != pb.Outcome_OUTCOME_COMPLETED {
+		t.Fatalf("expected a completed tomato and break, got %v", got)
+	}
+
+	recorded := got[0].GetInterruptions()
+	if len(recorded) != len(interruptions) {
+		t.Fatalf("expected %d interruptions recorded, got %v", len(interruptions), recorded)
+	}
+
+	for i, req := range interruptions {
+		kind := pb.InterruptionKind_INTERRUPTION_INTERNAL
+		if req.GetExternal() {
+			kind = pb.InterruptionKind_INTERRUPTION_EXTERNAL
+		}
+
+		at := got[0].GetStartedAt().AsTime().Add(time.Duration(i+1) * time.Minute)
+		if recorded[i].GetKind() != kind || recorded[i].GetNote() != req.GetNote() || !recorded[i].GetAt().AsTime().Equal(at) {
+			t.Errorf("expected %v %q at %v, got %v", kind, req.GetNote(), at, recorded[i])
+		}
+	}
+
+	// They belong to the tomato, not the break that follows.
+	if len(got[1].GetInterruptions()) != 0 {
+		t.Errorf("expected the break to have no interruptions, got %v", got[1].GetInterruptions())
+	}
+
+	stats, err := s.Stats(ctx, &pb.StatsRequest{})
+	if err != nil {
+		t.Fatalf("error getting stats: %v", err)
+	}
+
+	if stats.GetInternalInterruptions() != 1 || stats.GetExternalInterruptions() != 2 {
+		t.Fatalf("expected 1 internal and 2 external interruptions, got %d and %d", stats.GetInternalInterruptions(), stats.GetExternalInterruptions())
+	}
+}
+
+func TestWatchEvents(t *testing.T) {
+	s, _, fake := newFakeServer(t, "")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := &watchStream{ctx: ctx}
+	watched := make(chan error, 1)
+	go func() {
+		watched <- s.Watch(&pb.WatchRequest{TickInterval: durationpb.New(24 * time.Hour)}, stream)
+	}()
+
+	// Wait for Watch to subscribe, which it does before starting its ticker.
+	for fake.Pending() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err := s.Start(ctx, &pb.StartRequest{}); err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	fake.Advance(time.Minute)
+	if _, err := s.Pause(ctx, &emptypb.Empty{}); err != nil {
+		t.Fatalf("error pausing: %v", err)
+	}
+
+	fake.Advance(time.Minute)
+	if _, err := s.Resume(ctx, &emptypb.Empty{}); err != nil {
+		t.Fatalf("error resuming: %v", err)
+	}
+
+	fake.Advance(server.Duration - time.Minute)
+	fake.Advance(server.ShortBreak)
+
+	if _, err := s.Start(ctx, &pb.StartRequest{}); err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	if _, err := s.Stop(ctx, &pb.StopRequest{Reason: "lunch"}); err != nil {
+		t.Fatalf("error stopping: %v", err)
+	}
+
+	type event struct {
+		Type    pb.EventType
+		Phase   pb.Phase
+		State   pb.State
+		Outcome pb.Outcome
+	}
+
+	var (
+		work    = pb.Phase_PHASE_WORK
+		brk     = pb.Phase_PHASE_SHORT_BREAK
+		idle    = pb.Phase_PHASE_IDLE
+		running = pb.State_STATE_RUNNING
+		paused  = pb.State_STATE_PAUSED
+		stopped = pb.State_STATE_STOPPED
+	)
+
+	want := []event{
+		{pb.EventType_EVENT_PHASE_CHANGED, work, stopped, 0},
+		{pb.EventType_EVENT_STARTED, work, running, 0},
+		{pb.EventType_EVENT_PAUSED, work, paused, 0},
+		{pb.EventType_EVENT_RESUMED, work, running, 0},
+		{pb.EventType_EVENT_COMPLETED, work, stopped, pb.Outcome_OUTCOME_COMPLETED},
+		{pb.EventType_EVENT_PHASE_CHANGED, brk, stopped, 0},
+		{pb.EventType_EVENT_STARTED, brk, running, 0},
+		{pb.EventType_EVENT_COMPLETED, brk, stopped, pb.Outcome_OUTCOME_COMPLETED},
+		{pb.EventType_EVENT_PHASE_CHANGED, idle, stopped, 0},
+		{pb.EventType_EVENT_PHASE_CHANGED, work, stopped, 0},
+		{pb.EventType_EVENT_STARTED, work, running, 0},
+		{pb.EventType_EVENT_STOPPED, work, stopped, pb.Outcome_OUTCOME_STOPPED},
+		{pb.EventType_EVENT_PHASE_CHANGED, idle, stopped, 0},
+	}
+
+	var events []*pb.Event
+	for deadline := time.Now().Add(time.Second); len(events) < len(want) && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+
+		stream.mut.Lock()
+		events = append([]*pb.Event(nil), stream.events...)
+		stream.mut.Unlock()
+	}
+
+	var got []event
+	for _, e := range events {
+		got = append(got, event{e.GetType(), e.GetPhase(), e.GetState(), e.GetOutcome()})
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected events:\n%v\ngot:\n%v", want, got)
+	}
+
+	// Each event is stamped with when it happened, the break starting as
+	// the tomato completes.
+	started := events[1].GetAt().AsTime()
+	completed := started.Add(server.Duration + time.Minute)
+	if at := events[4].GetAt().AsTime(); !at.Equal(completed) {
+		t.Errorf("expected the tomato to complete at %v, having been paused for 1m, got %v", completed, at)
+	}
+
+	if at, ends := events[6].GetAt().AsTime(), events[6].GetEndsAt().AsTime(); !at.Equal(completed) || !ends.Equal(completed.Add(server.ShortBreak)) {
+		t.Errorf("expected the break to run from %v to %v, got %v to %v", completed, completed.Add(server.ShortBreak), at, ends)
+	}
+
+	if reason := events[11].GetReason(); reason != "lunch" {
+		t.Errorf("expected the stop reason on the stopped event, got %q", reason)
+	}
+
+	cancel()
+	if err := <-watched; err != nil {
+		t.Fatalf("error watching: %v", err)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	s, _, _ := newFakeServer(t, "")
+	ctx := context.Background()
+
+	// A server without history or tasks.
+	bare := server.New()
+	t.Cleanup(bare.Close)
+
+	call := func(_ interface{}, err error) error { return err }
+
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"pause stopped", call(s.Pause(ctx, &emptypb.Empty{})), codes.FailedPrecondition},
+		{"resume stopped", call(s.Resume(ctx, &emptypb.Empty{})), codes.FailedPrecondition},
+		{"interrupt stopped", call(s.Interrupt(ctx, &pb.InterruptRequest{})), codes.FailedPrecondition},
+		{"start too short", call(s.Start(ctx, &pb.StartRequest{Duration: durationpb.New(time.Second)})), codes.InvalidArgument},
+		{"start long label", call(s.Start(ctx, &pb.StartRequest{Label: strings.Repeat("a", server.MaxTextLength+1)})), codes.InvalidArgument},
+		{"start unknown task", call(s.Start(ctx, &pb.StartRequest{Task: 42})), codes.NotFound},
+		{"add untitled task", call(s.AddTask(ctx, &pb.AddTaskRequest{Title: " "})), codes.InvalidArgument},
+		{"complete unknown task", call(s.CompleteTask(ctx, &pb.CompleteTaskRequest{Id: 42})), codes.NotFound},
+		{"history disabled", call(bare.History(ctx, &pb.HistoryRequest{})), codes.FailedPrecondition},
+		{"tasks disabled", call(bare.ListTasks(ctx, &pb.ListTasksRequest{})), codes.FailedPrecondition},
+	}
+
+	for _, test := range tests {
+		if got := grpcstatus.Code(test.err); got != test.code {
+			t.Errorf("expected %v to fail with %v, got %v", test.name, test.code, test.err)
+		}
+	}
+
+	if _, err := s.Start(ctx, &pb.StartRequest{}); err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	if _, err := s.Start(ctx, &pb.StartRequest{}); grpcstatus.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected starting twice to fail with %v, got %v", codes.FailedPrecondition, err)
+	}
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/CGA1123/tomato/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// snapshot is the state of the timer as persisted to disk.
+type snapshot struct {
+	Phase     string        `json:"phase"`
+	Completed int           `json:"completed"`
+	Started   time.Time     `json:"started"`
+	Ends      time.Time     `json:"ends"`
+	Planned   time.Duration `json:"planned"`
+	Paused    bool          `json:"paused"`
+	PausedAt  time.Time     `json:"paused_at"`
+	Left      time.Duration `json:"left"`
+	Label     string        `json:"label,omitempty"`
+	Tags      []string      `json:"tags,omitempty"`
+	Task      uint32        `json:"task,omitempty"`
+
+	Interruptions []interruption `json:"interruptions,omitempty"`
+}
+
+type interruption struct {
+	Kind string    `json:"kind"`
+	At   time.Time `json:"at"`
+	Note string    `json:"note,omitempty"`
+}
+
+// StateFile persists the state of the timer so that it survives the server
+// restarting.
+type StateFile struct {
+	path string
+}
+
+func NewStateFile(path string) *StateFile {
+	return &StateFile{path: path}
+}
+
+// save atomically replaces the state file with snap.
+func (f *StateFile) save(snap *snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("error encoding state: %w", err)
+	}
+
+	return writeAtomic(f.path, data)
+}
+
+// writeAtomic replaces the file at path with data, such that it is never left
+// partially written.
+func writeAtomic(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tomato-")
+	if err != nil {
+		return fmt.Errorf("error creating %v: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing %v: %w", path, err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing %v: %w", path, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing %v: %w", path, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error replacing %v: %w", path, err)
+	}
+
+	return nil
+}
+
+// load returns the persisted state, or nil if there is none.
+func (f *StateFile) load() (*snapshot, error) {
+	data, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading state file: %w", err)
+	}
+
+	snap := &snapshot{}
+	if err := json.Unmarshal(data, snap); err != nil {
+		return nil, fmt.Errorf("error decoding state file: %w", err)
+	}
+
+	if _, ok := pb.Phase_value[snap.Phase]; !ok {
+		return nil, fmt.Errorf("error decoding state file: unknown phase %q", snap.Phase)
+	}
+
+	return snap, nil
+}
+
+// persist saves the current state of the timer, if enabled.
+func (s *Server) persist() {
+	if s.stateFile == nil || s.closed {
+		return
+	}
+
+	snap := &snapshot{
+		Phase:     s.phase.String(),
+		Completed: s.completed,
+		Started:   s.started,
+		Ends:      s.ends,
+		Planned:   s.planned,
+		Paused:    s.paused,
+		PausedAt:  s.pausedAt,
+		Left:      s.left,
+		Label:     s.label,
+		Tags:      s.tags,
+		Task:      s.task,
+	}
+
+	for _, i := range s.interruptions {
+		snap.Interruptions = append(snap.Interruptions, interruption{
+			Kind: i.GetKind().String(),
+			At:   i.GetAt().AsTime(),
+			Note: i.GetNote(),
+		})
+	}
+
+	if err := s.stateFile.save(snap); err != nil {
+		log.Printf("error persisting state: %v", err)
+	}
+}
+
+// restore resumes the timer from the state file, if enabled. A timer which
+// should have finished while the server was down completes at the time it was
+// due, as does any break following it which would also have finished.
+func (s *Server) restore() {
+	if s.stateFile == nil {
+		return
+	}
+
+	snap, err := s.stateFile.load()
+	if err != nil {
+		log.Printf("error restoring state: %v", err)
+		return
+	}
+
+	if snap == nil {
+		return
+	}
+
+	s.completed = snap.Completed
+
+	phase := pb.Phase(pb.Phase_value[snap.Phase])
+	if phase == pb.Phase_PHASE_IDLE {
+		return
+	}
+
+	s.phase = phase
+	s.announced = phase
+	s.started = snap.Started
+	s.planned = snap.Planned
+	s.label = snap.Label
+	s.tags = snap.Tags
+	s.task = snap.Task
+
+	for _, i := range snap.Interruptions {
+		s.interruptions = append(s.interruptions, &pb.Interruption{
+			Kind: pb.InterruptionKind(pb.InterruptionKind_value[i.Kind]),
+			At:   timestamppb.New(i.At),
+			Note: i.Note,
+		})
+	}
+
+	if snap.Paused {
+		s.run(s.clock.Now().Add(snap.Left))
+		s.generation++
+		s.tomato.Stop()
+		s.paused = true
+		s.pausedAt = snap.PausedAt
+		s.left = snap.Left
+		s.persist()
+
+		return
+	}
+
+	s.run(snap.Ends)
+	for s.tomato != nil && !s.ends.After(s.clock.Now()) {
+		s.complete()
+	}
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"sort"
+	"time"
+
+	"github.com/CGA1123/tomato/pb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const dayFormat = "2006-01-02"
+
+// Stats aggregates the tomatoes within sessions started within [since,
+// until). Days are bucketed in local time, and when either end of the range
+// is unbounded it is taken to be the first session or now respectively. Days
+// after now are left out, whatever until is.
+//
+// Streaks are counted over every session given, not just those in range, so
+// that they reflect the whole history up to until.
+func Stats(sessions []*pb.Session, since, until, now time.Time) *pb.StatsResponse {
+	stats := &pb.StatsResponse{}
+	days := map[string]*pb.DayStats{}
+	completed := map[string]bool{}
+	tags := map[string]*pb.TagStats{}
+	focused := time.Duration(0)
+	first := time.Time{}
+
+	for _, session := range sessions {
+		if session.GetPhase() != pb.Phase_PHASE_WORK {
+			continue
+		}
+
+		started := session.GetStartedAt().AsTime().Local()
+		if first.IsZero() || started.Before(first) {
+			first = started
+		}
+
+		date := started.Format(dayFormat)
+		if session.GetOutcome() == pb.Outcome_OUTCOME_COMPLETED {
+			completed[date] = true
+		}
+
+		if !within(started, since, until) {
+			continue
+		}
+
+		day, ok := days[date]
+		if !ok {
+			day = &pb.DayStats{Date: date, Focused: durationpb.New(0)}
+			days[date] = day
+		}
+
+		actual := session.GetActual().AsDuration()
+		focused += actual
+		day.Focused = durationpb.New(day.GetFocused().AsDuration() + actual)
+
+		for _, name := range session.GetTags() {
+			tag, ok := tags[name]
+			if !ok {
+				tag = &pb.TagStats{Tag: name, Focused: durationpb.New(0)}
+				tags[name] = tag
+				stats.Tags = append(stats.Tags, tag)
+			}
+
+			tag.Focused = durationpb.New(tag.GetFocused().AsDuration() + actual)
+			if session.GetOutcome() == pb.Outcome_OUTCOME_COMPLETED {
+				tag.Completed++
+			}
+		}
+
+		for _, interruption := range session.GetInterruptions() {
+			switch interruption.GetKind() {
+			case pb.InterruptionKind_INTERRUPTION_EXTERNAL:
+				stats.ExternalInterruptions++
+			default:
+				stats.InternalInterruptions++
+			}
+		}
+
+		switch session.GetOutcome() {
+		case pb.Outcome_OUTCOME_COMPLETED:
+			stats.Completed++
+			day.Completed++
+		case pb.Outcome_OUTCOME_STOPPED:
+			stats.Stopped++
+		case pb.Outcome_OUTCOME_ABANDONED:
+			stats.Abandoned++
+		}
+	}
+
+	stats.Focused = durationpb.New(focused)
+
+	sort.Slice(stats.Tags, func(i, j int) bool {
+		return stats.Tags[i].GetTag() < stats.Tags[j].GetTag()
+	})
+
+	if total := stats.Completed + stats.Stopped + stats.Abandoned; total > 0 {
+		stats.CompletionRate = float64(stats.Completed) / float64(total)
+	}
+
+	if first.IsZero() {
+		return stats
+	}
+
+	// Days yet to come are neither reported nor break a streak.
+	last := now
+	if !until.IsZero() && until.Before(now) {
+		last = until.Add(-time.Nanosecond)
+	}
+
+	if since.IsZero() {
+		since = first
+	}
+
+	for day := midnight(since); !day.After(last); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dayFormat)
+		stat, ok := days[date]
+		if !ok {
+			stat = &pb.DayStats{Date: date, Focused: durationpb.New(0)}
+		}
+
+		stats.Days = append(stats.Days, stat)
+	}
+
+	today := midnight(now).Format(dayFormat)
+	streak := uint32(0)
+
+	for day := midnight(first); !day.After(last); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dayFormat)
+		if completed[date] {
+			streak++
+		} else if date != today {
+			// A day without a tomato only breaks the streak once it is
+			// over, today may yet have one.
+			streak = 0
+		}
+
+		if streak > stats.LongestStreak {
+			stats.LongestStreak = streak
+		}
+	}
+
+	stats.CurrentStreak = streak
+
+	return stats
+}
+
+func midnight(t time.Time) time.Time {
+	t = t.Local()
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
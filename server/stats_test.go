@@ -0,0 +1,177 @@
+package server_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/CGA1123/tomato/pb"
+	"github.com/CGA1123/tomato/server"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func session(started time.Time, outcome pb.Outcome) *pb.Session {
+	return &pb.Session{
+		Phase:     pb.Phase_PHASE_WORK,
+		StartedAt: timestamppb.New(started),
+		Planned:   durationpb.New(server.Duration),
+		Actual:    durationpb.New(server.Duration),
+		Outcome:   outcome,
+	}
+}
+
+func TestStatsStreaks(t *testing.T) {
+	now := time.Date(2021, 6, 30, 18, 0, 0, 0, time.Local)
+	today := time.Date(2021, 6, 30, 9, 0, 0, 0, time.Local)
+
+	var sessions []*pb.Session
+
+	// A 5 day streak a month ago, then a gap, and 10 days up to yesterday.
+	for i := 35; i > 30; i-- {
+		sessions = append(sessions, session(today.AddDate(0, 0, -i), pb.Outcome_OUTCOME_COMPLETED))
+	}
+
+	for i := 10; i > 0; i-- {
+		sessions = append(sessions, session(today.AddDate(0, 0, -i), pb.Outcome_OUTCOME_COMPLETED))
+	}
+
+	sessions = append(sessions, session(today, pb.Outcome_OUTCOME_STOPPED))
+
+	since := time.Date(2021, 6, 28, 0, 0, 0, 0, time.Local)
+	stats := server.Stats(sessions, since, time.Time{}, now)
+
+	if stats.GetCompleted() != 2 || stats.GetStopped() != 1 {
+		t.Fatalf("expected 2 completed and 1 stopped within the window, got %d and %d", stats.GetCompleted(), stats.GetStopped())
+	}
+
+	if len(stats.GetDays()) != 3 {
+		t.Fatalf("expected 3 days within the window, got %d", len(stats.GetDays()))
+	}
+
+	// Today has no completed tomato yet, which does not break the streak.
+	if stats.GetCurrentStreak() != 10 || stats.GetLongestStreak() != 10 {
+		t.Fatalf("expected streaks of 10 days from before the window, got %d current and %d longest", stats.GetCurrentStreak(), stats.GetLongestStreak())
+	}
+
+	// An until in the future counts up to now, not through days to come.
+	stats = server.Stats(sessions, since, today.AddDate(0, 0, 2), now)
+
+	if stats.GetCurrentStreak() != 10 || stats.GetLongestStreak() != 10 {
+		t.Fatalf("expected streaks of 10 days with until in the future, got %d current and %d longest", stats.GetCurrentStreak(), stats.GetLongestStreak())
+	}
+
+	if len(stats.GetDays()) != 3 {
+		t.Fatalf("expected no days after today, got %d days", len(stats.GetDays()))
+	}
+
+	// Streaks are counted up to until.
+	until := today.AddDate(0, 0, -29)
+	stats = server.Stats(sessions, time.Time{}, time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, time.Local), now)
+
+	if stats.GetCurrentStreak() != 0 || stats.GetLongestStreak() != 5 {
+		t.Fatalf("expected a broken streak of 5 days before until, got %d current and %d longest", stats.GetCurrentStreak(), stats.GetLongestStreak())
+	}
+}
+
+func TestStatsTags(t *testing.T) {
+	now := time.Date(2021, 6, 30, 18, 0, 0, 0, time.Local)
+	today := time.Date(2021, 6, 30, 9, 0, 0, 0, time.Local)
+
+	tagged := func(outcome pb.Outcome, actual time.Duration, tags ...string) *pb.Session {
+		s := session(today, outcome)
+		s.Actual = durationpb.New(actual)
+		s.Tags = tags
+
+		return s
+	}
+
+	type tag struct {
+		completed uint32
+		focused   time.Duration
+	}
+
+	tests := []struct {
+		name     string
+		sessions []*pb.Session
+		want     map[string]tag
+		order    []string
+	}{
+		{
+			name:     "untagged",
+			sessions: []*pb.Session{tagged(pb.Outcome_OUTCOME_COMPLETED, 25*time.Minute)},
+			want:     map[string]tag{},
+		},
+		{
+			name: "focus counts whether completed or not",
+			sessions: []*pb.Session{
+				tagged(pb.Outcome_OUTCOME_COMPLETED, 25*time.Minute, "review"),
+				tagged(pb.Outcome_OUTCOME_STOPPED, 10*time.Minute, "review"),
+				tagged(pb.Outcome_OUTCOME_ABANDONED, 5*time.Minute, "review"),
+			},
+			want:  map[string]tag{"review": {1, 40 * time.Minute}},
+			order: []string{"review"},
+		},
+		{
+			name: "several tags count against each",
+			sessions: []*pb.Session{
+				tagged(pb.Outcome_OUTCOME_COMPLETED, 25*time.Minute, "review", "backend"),
+				tagged(pb.Outcome_OUTCOME_COMPLETED, 25*time.Minute, "backend"),
+			},
+			want:  map[string]tag{"backend": {2, 50 * time.Minute}, "review": {1, 25 * time.Minute}},
+			order: []string{"backend", "review"},
+		},
+		{
+			name: "sorted by name",
+			sessions: []*pb.Session{
+				tagged(pb.Outcome_OUTCOME_COMPLETED, 25*time.Minute, "writing"),
+				tagged(pb.Outcome_OUTCOME_COMPLETED, 25*time.Minute, "admin"),
+				tagged(pb.Outcome_OUTCOME_COMPLETED, 25*time.Minute, "meetings"),
+			},
+			want: map[string]tag{
+				"admin":    {1, 25 * time.Minute},
+				"meetings": {1, 25 * time.Minute},
+				"writing":  {1, 25 * time.Minute},
+			},
+			order: []string{"admin", "meetings", "writing"},
+		},
+		{
+			name: "breaks don't count",
+			sessions: []*pb.Session{
+				{
+					Phase:     pb.Phase_PHASE_SHORT_BREAK,
+					StartedAt: timestamppb.New(today),
+					Actual:    durationpb.New(5 * time.Minute),
+					Outcome:   pb.Outcome_OUTCOME_COMPLETED,
+					Tags:      []string{"review"},
+				},
+			},
+			want: map[string]tag{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stats := server.Stats(test.sessions, time.Time{}, time.Time{}, now)
+
+			var order []string
+			for _, got := range stats.GetTags() {
+				order = append(order, got.GetTag())
+
+				want, ok := test.want[got.GetTag()]
+				if !ok {
+					t.Errorf("unexpected tag %q", got.GetTag())
+					continue
+				}
+
+				if got.GetCompleted() != want.completed || got.GetFocused().AsDuration() != want.focused {
+					t.Errorf("expected %q to have %d completed and %v focused, got %d and %v", got.GetTag(), want.completed, want.focused, got.GetCompleted(), got.GetFocused().AsDuration())
+				}
+			}
+
+			if !reflect.DeepEqual(order, test.order) {
+				t.Errorf("expected tags %v, got %v", test.order, order)
+			}
+		})
+	}
+}
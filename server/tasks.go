@@ -0,0 +1,189 @@
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/CGA1123/tomato/clock"
+	"github.com/CGA1123/tomato/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Tasks is a todo list of tasks for tomatoes to be counted against, stored on
+// disk as a JSON encoded pb.ListTasksResponse.
+type Tasks struct {
+	mut   sync.Mutex
+	path  string
+	clock clock.Clock
+}
+
+func NewTasks(path string) *Tasks {
+	return &Tasks{path: path, clock: clock.Real}
+}
+
+// setClock timestamps tasks using c, so they agree with the server they are
+// kept for.
+func (t *Tasks) setClock(c clock.Clock) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	t.clock = c
+}
+
+// Add creates a new task, expected to take estimate tomatoes.
+func (t *Tasks) Add(title string, estimate uint32) (*pb.Task, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, status.Error(codes.InvalidArgument, "task must have a title")
+	}
+
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	tasks, err := t.load()
+	if err != nil {
+		return nil, err
+	}
+
+	task := &pb.Task{
+		Id:        1,
+		Title:     title,
+		Estimate:  estimate,
+		CreatedAt: timestamppb.New(t.clock.Now()),
+	}
+
+	for _, existing := range tasks {
+		if existing.GetId() >= task.Id {
+			task.Id = existing.GetId() + 1
+		}
+	}
+
+	if err := t.save(append(tasks, task)); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// List returns the tasks not yet done, or every task if all is set, in the
+// order they were added.
+func (t *Tasks) List(all bool) ([]*pb.Task, error) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	tasks, err := t.load()
+	if err != nil {
+		return nil, err
+	}
+
+	if all {
+		return tasks, nil
+	}
+
+	todo := []*pb.Task{}
+	for _, task := range tasks {
+		if !task.GetDone() {
+			todo = append(todo, task)
+		}
+	}
+
+	return todo, nil
+}
+
+// Get returns the task with the given id.
+func (t *Tasks) Get(id uint32) (*pb.Task, error) {
+	return t.update(id, func(*pb.Task) bool { return false })
+}
+
+// Complete marks the task with the given id as done.
+func (t *Tasks) Complete(id uint32) (*pb.Task, error) {
+	return t.update(id, func(task *pb.Task) bool {
+		if task.GetDone() {
+			return false
+		}
+
+		task.Done = true
+		task.DoneAt = timestamppb.New(t.clock.Now())
+
+		return true
+	})
+}
+
+// Estimate changes how many tomatoes the task with the given id is expected
+// to take.
+func (t *Tasks) Estimate(id, estimate uint32) (*pb.Task, error) {
+	return t.update(id, func(task *pb.Task) bool {
+		task.Estimate = estimate
+		return true
+	})
+}
+
+// Count records a completed tomato against the task with the given id.
+func (t *Tasks) Count(id uint32) (*pb.Task, error) {
+	return t.update(id, func(task *pb.Task) bool {
+		task.Tomatoes++
+		return true
+	})
+}
+
+// update applies f to the task with the given id, saving the tasks if it
+// reports a change.
+func (t *Tasks) update(id uint32, f func(*pb.Task) bool) (*pb.Task, error) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	tasks, err := t.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, task := range tasks {
+		if task.GetId() != id {
+			continue
+		}
+
+		if !f(task) {
+			return task, nil
+		}
+
+		if err := t.save(tasks); err != nil {
+			return nil, err
+		}
+
+		return task, nil
+	}
+
+	return nil, status.Errorf(codes.NotFound, "no task with id %d", id)
+}
+
+func (t *Tasks) load() ([]*pb.Task, error) {
+	data, err := ioutil.ReadFile(t.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading tasks: %w", err)
+	}
+
+	list := &pb.ListTasksResponse{}
+	if err := protojson.Unmarshal(data, list); err != nil {
+		return nil, fmt.Errorf("error decoding tasks: %w", err)
+	}
+
+	return list.GetTasks(), nil
+}
+
+func (t *Tasks) save(tasks []*pb.Task) error {
+	data, err := protojson.Marshal(&pb.ListTasksResponse{Tasks: tasks})
+	if err != nil {
+		return fmt.Errorf("error encoding tasks: %w", err)
+	}
+
+	return writeAtomic(t.path, data)
+}
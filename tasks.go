@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/CGA1123/tomato/client"
+	"github.com/CGA1123/tomato/pb"
+	"github.com/spf13/cobra"
+)
+
+type taskResult struct {
+	ID        uint32     `json:"id"`
+	Title     string     `json:"title"`
+	Estimate  uint32     `json:"estimate"`
+	Tomatoes  uint32     `json:"tomatoes"`
+	Done      bool       `json:"done"`
+	CreatedAt time.Time  `json:"created_at"`
+	DoneAt    *time.Time `json:"done_at,omitempty"`
+}
+
+func newTaskResult(task *pb.Task) taskResult {
+	return taskResult{
+		ID:        task.GetId(),
+		Title:     task.GetTitle(),
+		Estimate:  task.GetEstimate(),
+		Tomatoes:  task.GetTomatoes(),
+		Done:      task.GetDone(),
+		CreatedAt: task.GetCreatedAt().AsTime(),
+		DoneAt:    optionalTime(task.GetDoneAt()),
+	}
+}
+
+// describeProgress formats how many tomatoes a task has taken against its
+// estimate, e.g. 2/4.
+func describeProgress(task *pb.Task) string {
+	if task.GetEstimate() == 0 {
+		return strconv.Itoa(int(task.GetTomatoes()))
+	}
+
+	return fmt.Sprintf("%d/%d", task.GetTomatoes(), task.GetEstimate())
+}
+
+func parseTaskID(value string) (uint32, error) {
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid task id %q", value)
+	}
+
+	return uint32(id), nil
+}
+
+func task() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "task",
+		Short: "Manages the todo list of tasks to spend tomatoes on.",
+	}
+
+	cmd.AddCommand(taskAdd(), taskList(), taskDone(), taskEstimate())
+
+	return cmd
+}
+
+func taskAdd() *cobra.Command {
+	var estimate uint32
+
+	cmd := &cobra.Command{
+		Use:   "add <title>",
+		Short: "Adds a task to the todo list.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return WithClient(func(c *client.Client) error {
+				task, err := c.AddTask(args[0], estimate)
+				if err != nil {
+					return err
+				}
+
+				return report(newTaskResult(task), func() {
+					log.Printf("added task %d: %v", task.GetId(), task.GetTitle())
+					log.Printf("use `tomato start --task %d` to work on it.", task.GetId())
+				}, func() {
+					fmt.Println(task.GetId())
+				})
+			})
+		},
+	}
+
+	cmd.Flags().Uint32VarP(&estimate, "estimate", "e", 0, "how many tomatoes the task is expected to take")
+
+	return cmd
+}
+
+func taskList() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the tasks not yet done.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return WithClient(func(c *client.Client) error {
+				tasks, err := c.ListTasks(all)
+				if err != nil {
+					return err
+				}
+
+				results := make([]taskResult, 0, len(tasks))
+				for _, task := range tasks {
+					results = append(results, newTaskResult(task))
+				}
+
+				table := func(header bool) func() {
+					return func() {
+						w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+						if header {
+							fmt.Fprintln(w, "ID\tTOMATOES\tDONE\tTITLE")
+						}
+
+						for _, task := range tasks {
+							done := ""
+							if task.GetDone() {
+								done = task.GetDoneAt().AsTime().Local().Format("2006-01-02")
+							}
+
+							fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", task.GetId(), describeProgress(task), done, task.GetTitle())
+						}
+
+						w.Flush()
+					}
+				}
+
+				return report(results, table(true), table(false))
+			})
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "include tasks which are done")
+
+	return cmd
+}
+
+func taskDone() *cobra.Command {
+	return &cobra.Command{
+		Use:   "done <id>",
+		Short: "Marks a task as done.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseTaskID(args[0])
+			if err != nil {
+				return err
+			}
+
+			return WithClient(func(c *client.Client) error {
+				task, err := c.CompleteTask(id)
+				if err != nil {
+					return err
+				}
+
+				return report(newTaskResult(task), func() {
+					log.Printf("task %d done after %v tomato(es): %v", task.GetId(), describeProgress(task), task.GetTitle())
+				}, func() {})
+			})
+		},
+	}
+}
+
+func taskEstimate() *cobra.Command {
+	return &cobra.Command{
+		Use:   "estimate <id> <tomatoes>",
+		Short: "Changes how many tomatoes a task is expected to take.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseTaskID(args[0])
+			if err != nil {
+				return err
+			}
+
+			estimate, err := strconv.ParseUint(args[1], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid estimate %q", args[1])
+			}
+
+			return WithClient(func(c *client.Client) error {
+				task, err := c.EstimateTask(id, uint32(estimate))
+				if err != nil {
+					return err
+				}
+
+				return report(newTaskResult(task), func() {
+					log.Printf("task %d is now at %v tomato(es): %v", task.GetId(), describeProgress(task), task.GetTitle())
+				}, func() {})
+			})
+		},
+	}
+}
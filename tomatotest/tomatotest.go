@@ -0,0 +1,79 @@
+// Package tomatotest runs a tomato server in-process for end-to-end tests,
+// reached over an in-memory listener rather than a socket on disk.
+package tomatotest
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/CGA1123/tomato/client"
+	"github.com/CGA1123/tomato/pb"
+	"github.com/CGA1123/tomato/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// bufferSize is how much each connection buffers in either direction.
+const bufferSize = 1024 * 1024
+
+// Server is a tomato server running in-process, embedded so that tests can
+// reach into it directly.
+type Server struct {
+	*server.Server
+
+	lis *bufconn.Listener
+}
+
+// New starts a server configured by opts and returns a client connected to
+// it. Both are shut down once the test finishes.
+func New(t testing.TB, opts ...server.Option) *client.Client {
+	t.Helper()
+
+	srv := Start(t, opts...)
+
+	c, err := srv.Dial()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	return c
+}
+
+// Start starts a server configured by opts, shut down once the test finishes.
+//
+// Without options the server keeps nothing on disk, so has no history, tasks
+// or state to restore. Tests wanting those should pass server.WithHistory,
+// server.WithTasks or server.WithStateFile pointing into t.TempDir().
+func Start(t testing.TB, opts ...server.Option) *Server {
+	t.Helper()
+
+	lis := bufconn.Listen(bufferSize)
+	tomato := server.New(opts...)
+
+	srv := grpc.NewServer()
+	pb.RegisterTomatoServiceServer(srv, tomato)
+
+	go func() {
+		// Serve only returns once the server is stopped below.
+		_ = srv.Serve(lis)
+	}()
+
+	t.Cleanup(func() {
+		srv.Stop()
+		tomato.Close()
+	})
+
+	return &Server{Server: tomato, lis: lis}
+}
+
+// Dial returns a new client connected to the server, configured by opts,
+// which the caller should close.
+func (s *Server) Dial(opts ...client.Option) (*client.Client, error) {
+	opts = append(opts, client.WithDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return s.lis.Dial()
+	}))
+
+	return client.New("bufconn", opts...)
+}
@@ -0,0 +1,76 @@
+package tomatotest_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CGA1123/tomato/client"
+	"github.com/CGA1123/tomato/clock"
+	"github.com/CGA1123/tomato/pb"
+	"github.com/CGA1123/tomato/server"
+	"github.com/CGA1123/tomato/tomatotest"
+)
+
+func TestClient(t *testing.T) {
+	fake := clock.NewFake(time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC))
+	c := tomatotest.New(t,
+		server.WithClock(fake),
+		server.WithHistory(server.NewHistory(filepath.Join(t.TempDir(), "history"))),
+	)
+
+	ends, err := c.StartWith(client.StartOptions{Label: "end to end"})
+	if err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	if want := fake.Now().Add(server.Duration); !ends.Equal(want) {
+		t.Fatalf("expected tomato to end at %v, got %v", want, ends)
+	}
+
+	fake.Advance(5 * time.Minute)
+
+	status, err := c.Status()
+	if err != nil {
+		t.Fatalf("error getting status: %v", err)
+	}
+
+	if status.GetState() != pb.State_STATE_RUNNING || status.GetLabel() != "end to end" {
+		t.Fatalf("expected a running tomato labelled %q, got %v %q", "end to end", status.GetState(), status.GetLabel())
+	}
+
+	if got := status.GetRemaining().AsDuration(); got != server.Duration-5*time.Minute {
+		t.Fatalf("expected %v remaining, got %v", server.Duration-5*time.Minute, got)
+	}
+
+	if _, err := c.StopWithReason("done testing"); err != nil {
+		t.Fatalf("error stopping: %v", err)
+	}
+
+	sessions, err := c.History(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("error getting history: %v", err)
+	}
+
+	if len(sessions) != 1 || sessions[0].GetReason() != "done testing" {
+		t.Fatalf("expected the stopped tomato in history, got %v", sessions)
+	}
+}
+
+func TestServersAreIndependent(t *testing.T) {
+	first := tomatotest.New(t)
+	second := tomatotest.New(t)
+
+	if _, err := first.Start(0); err != nil {
+		t.Fatalf("error starting: %v", err)
+	}
+
+	state, err := second.Running()
+	if err != nil {
+		t.Fatalf("error checking running: %v", err)
+	}
+
+	if state != pb.State_STATE_STOPPED {
+		t.Fatalf("expected the second server to be stopped, got %v", state)
+	}
+}